@@ -1,12 +1,14 @@
 package main
 
 import (
+	"fmt"
 	"log"
 
 	"byeboros-backend/config"
 	apphttp "byeboros-backend/internal/adapter/http"
 	"byeboros-backend/internal/adapter/http/controller"
 	"byeboros-backend/internal/adapter/repository"
+	"byeboros-backend/internal/infrastructure/blobstore"
 	"byeboros-backend/internal/infrastructure/gsheet"
 	"byeboros-backend/internal/usecase"
 
@@ -25,16 +27,34 @@ func main() {
 		log.Println("   The server will start, but sheet operations will not work.")
 	}
 
+	// Initialize the receipt attachment store
+	attachmentStore, err := newAttachmentStore(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize attachment store: %v", err)
+	}
+
 	// Initialize layers
 	sheetRepo := repository.NewSheetRepository(sheetClient)
 	authUsecase := usecase.NewAuthUsecase(cfg)
-	transactionUsecase := usecase.NewTransactionUsecase(sheetRepo)
-	categoryUsecase := usecase.NewCategoryUsecase(sheetRepo)
+	transactionUsecase := usecase.NewTransactionUsecase(sheetRepo, attachmentStore, cfg.BaseCurrency)
+	categoryUsecase := usecase.NewCategoryUsecase(sheetRepo, cfg.BaseCurrency)
+	accountUsecase := usecase.NewAccountUsecase(sheetRepo, cfg.BaseCurrency)
+	exchangeRateUsecase := usecase.NewExchangeRateUsecase(sheetRepo, cfg.BaseCurrency)
+	goalUsecase := usecase.NewGoalUsecase(sheetRepo, cfg.BaseCurrency)
+	debtUsecase := usecase.NewDebtUsecase(sheetRepo, cfg.BaseCurrency)
+	budgetUsecase := usecase.NewBudgetUsecase(sheetRepo, cfg.BaseCurrency)
+	attachmentUsecase := usecase.NewAttachmentUsecase(sheetRepo, attachmentStore, int64(cfg.AttachmentMaxSizeMB)<<20)
 
 	// Controllers
 	authController := controller.NewAuthController(authUsecase)
 	transactionController := controller.NewTransactionController(transactionUsecase)
 	categoryController := controller.NewCategoryController(categoryUsecase)
+	accountController := controller.NewAccountController(accountUsecase)
+	exchangeRateController := controller.NewExchangeRateController(exchangeRateUsecase)
+	goalController := controller.NewGoalController(goalUsecase)
+	debtController := controller.NewDebtController(debtUsecase)
+	budgetController := controller.NewBudgetController(budgetUsecase)
+	attachmentController := controller.NewAttachmentController(attachmentUsecase)
 
 	// Setup Echo
 	e := echo.New()
@@ -50,9 +70,29 @@ func main() {
 	}))
 
 	// Register routes
-	apphttp.SetupRoutes(e, authController, transactionController, categoryController, authUsecase)
+	apphttp.SetupRoutes(e, authController, transactionController, categoryController, accountController, exchangeRateController, goalController, debtController, budgetController, attachmentController, authUsecase)
 
 	// Start server
 	log.Printf("Server starting on port %s", cfg.Port)
 	e.Logger.Fatal(e.Start(":" + cfg.Port))
 }
+
+// newAttachmentStore creates the blob store configured by ATTACHMENT_STORE
+func newAttachmentStore(cfg *config.Config) (blobstore.Store, error) {
+	switch cfg.AttachmentStore {
+	case "s3":
+		log.Printf("Storing attachments in S3 bucket %s", cfg.S3Bucket)
+		return blobstore.NewS3Store(blobstore.S3Config{
+			Endpoint:     cfg.S3Endpoint,
+			Bucket:       cfg.S3Bucket,
+			Region:       cfg.S3Region,
+			AccessKey:    cfg.S3AccessKey,
+			SecretKey:    cfg.S3SecretKey,
+			UsePathStyle: cfg.S3UsePathStyle,
+		})
+	case "local", "":
+		log.Printf("Storing attachments in %s", cfg.AttachmentDir)
+		return blobstore.NewLocalStore(cfg.AttachmentDir)
+	}
+	return nil, fmt.Errorf("unknown ATTACHMENT_STORE %q (must be 'local' or 's3')", cfg.AttachmentStore)
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/joho/godotenv"
@@ -17,6 +18,18 @@ type Config struct {
 	JWTSecret            string
 	FrontendURL          string
 	AllowedOrigins       []string
+	BaseCurrency         string
+
+	// Receipt attachments
+	AttachmentStore     string // "local" or "s3"
+	AttachmentDir       string
+	AttachmentMaxSizeMB int
+	S3Endpoint          string
+	S3Bucket            string
+	S3Region            string
+	S3AccessKey         string
+	S3SecretKey         string
+	S3UsePathStyle      bool
 }
 
 func LoadConfig() *Config {
@@ -34,6 +47,16 @@ func LoadConfig() *Config {
 		JWTSecret:            getEnv("JWT_SECRET", "secret"),
 		FrontendURL:          getEnv("FRONTEND_URL", "http://localhost:3000"),
 		AllowedOrigins:       strings.Split(getEnv("ALLOWED_ORIGINS", "http://localhost:3000"), ","),
+		BaseCurrency:         strings.ToUpper(strings.TrimSpace(getEnv("BASE_CURRENCY", "IDR"))),
+		AttachmentStore:      getEnv("ATTACHMENT_STORE", "local"),
+		AttachmentDir:        getEnv("ATTACHMENT_DIR", "data/attachments"),
+		AttachmentMaxSizeMB:  getEnvInt("ATTACHMENT_MAX_SIZE_MB", 5),
+		S3Endpoint:           getEnv("S3_ENDPOINT", ""),
+		S3Bucket:             getEnv("S3_BUCKET", ""),
+		S3Region:             getEnv("S3_REGION", "us-east-1"),
+		S3AccessKey:          getEnv("S3_ACCESS_KEY", ""),
+		S3SecretKey:          getEnv("S3_SECRET_KEY", ""),
+		S3UsePathStyle:       getEnv("S3_USE_PATH_STYLE", "true") == "true",
 	}
 }
 
@@ -43,3 +66,11 @@ func getEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+func getEnvInt(key string, fallback int) int {
+	value, err := strconv.Atoi(getEnv(key, ""))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"byeboros-backend/internal/adapter/http/model/request"
+	"byeboros-backend/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+// AccountController handles account and wallet HTTP endpoints
+type AccountController struct {
+	accountUsecase *usecase.AccountUsecase
+}
+
+// NewAccountController creates a new AccountController
+func NewAccountController(accountUsecase *usecase.AccountUsecase) *AccountController {
+	return &AccountController{accountUsecase: accountUsecase}
+}
+
+// ListAccount handles GET /api/accounts
+func (h *AccountController) ListAccount(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Spreadsheet ID not found in context",
+		})
+	}
+
+	data, err := h.accountUsecase.GetAccounts(spreadsheetID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to fetch accounts: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   data,
+	})
+}
+
+// ListAccountBalance handles GET /api/accounts/:id/balances
+func (h *AccountController) ListAccountBalance(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Spreadsheet ID not found in context",
+		})
+	}
+
+	data, err := h.accountUsecase.GetAccountBalances(spreadsheetID, c.Param("id"))
+	if err != nil {
+		if errors.Is(err, usecase.ErrAccountNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to fetch account balances: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   data,
+	})
+}
+
+// CreateAccount handles POST /api/accounts
+func (h *AccountController) CreateAccount(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Spreadsheet ID not found in context",
+		})
+	}
+
+	var req request.CreateAccountRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request payload: " + err.Error(),
+		})
+	}
+
+	// Basic validation
+	if req.Name == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "name is required",
+		})
+	}
+
+	switch req.Type {
+	case "cash", "bank", "ewallet", "credit", "other":
+	default:
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "type must be one of: cash, bank, ewallet, credit, other",
+		})
+	}
+
+	data, err := h.accountUsecase.CreateAccount(spreadsheetID, req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to create account: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"message": "Account created successfully",
+		"data":    data,
+	})
+}
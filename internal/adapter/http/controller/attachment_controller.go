@@ -0,0 +1,183 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"byeboros-backend/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+// AttachmentController handles receipt attachment HTTP endpoints
+type AttachmentController struct {
+	attachmentUsecase *usecase.AttachmentUsecase
+}
+
+// NewAttachmentController creates a new AttachmentController
+func NewAttachmentController(attachmentUsecase *usecase.AttachmentUsecase) *AttachmentController {
+	return &AttachmentController{attachmentUsecase: attachmentUsecase}
+}
+
+// attachmentStatus maps attachment usecase errors to HTTP status codes
+func attachmentStatus(err error) int {
+	switch {
+	case errors.Is(err, usecase.ErrAttachmentNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, usecase.ErrAttachmentTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, usecase.ErrAttachmentType):
+		return http.StatusUnsupportedMediaType
+	}
+	return http.StatusInternalServerError
+}
+
+// UploadAttachment handles POST /api/transaction/:id/attachments (multipart field "file")
+func (h *AttachmentController) UploadAttachment(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Spreadsheet ID not found in context",
+		})
+	}
+
+	sheetName, ok := c.Get("sheet_name").(string)
+	if !ok || sheetName == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Sheet name not found in context",
+		})
+	}
+
+	// Reject oversized bodies before the multipart form is parsed
+	maxSize := h.attachmentUsecase.MaxSize()
+	c.Request().Body = http.MaxBytesReader(c.Response(), c.Request().Body, maxSize+1<<20)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return c.JSON(http.StatusRequestEntityTooLarge, map[string]string{
+				"error": fmt.Sprintf("file must not be larger than %d MB", maxSize/(1<<20)),
+			})
+		}
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "file is required",
+		})
+	}
+	if fileHeader.Size > maxSize {
+		return c.JSON(http.StatusRequestEntityTooLarge, map[string]string{
+			"error": fmt.Sprintf("file must not be larger than %d MB", maxSize/(1<<20)),
+		})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Failed to read file: " + err.Error(),
+		})
+	}
+	defer file.Close()
+
+	data, err := h.attachmentUsecase.AddAttachment(c.Request().Context(), spreadsheetID, sheetName, c.Param("id"), file)
+	if err != nil {
+		return c.JSON(attachmentStatus(err), map[string]string{
+			"error": "Failed to upload attachment: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"message": "Attachment uploaded successfully",
+		"data":    data,
+	})
+}
+
+// ListAttachment handles GET /api/transaction/:id/attachments
+func (h *AttachmentController) ListAttachment(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Spreadsheet ID not found in context",
+		})
+	}
+
+	sheetName, ok := c.Get("sheet_name").(string)
+	if !ok || sheetName == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Sheet name not found in context",
+		})
+	}
+
+	data, err := h.attachmentUsecase.GetAttachments(spreadsheetID, sheetName, c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to fetch attachments: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   data,
+	})
+}
+
+// DownloadAttachment handles GET /api/transaction/:id/attachments/:attachment_id
+func (h *AttachmentController) DownloadAttachment(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Spreadsheet ID not found in context",
+		})
+	}
+
+	sheetName, ok := c.Get("sheet_name").(string)
+	if !ok || sheetName == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Sheet name not found in context",
+		})
+	}
+
+	attachmentID := c.Param("attachment_id")
+	rc, contentType, err := h.attachmentUsecase.OpenAttachment(c.Request().Context(), spreadsheetID, sheetName, c.Param("id"), attachmentID)
+	if err != nil {
+		return c.JSON(attachmentStatus(err), map[string]string{
+			"error": "Failed to download attachment: " + err.Error(),
+		})
+	}
+	defer rc.Close()
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("inline; filename=%q", attachmentID))
+	c.Response().Header().Set(echo.HeaderContentType, contentType)
+	c.Response().WriteHeader(http.StatusOK)
+	_, err = io.Copy(c.Response(), rc)
+	return err
+}
+
+// DeleteAttachment handles DELETE /api/transaction/:id/attachments/:attachment_id
+func (h *AttachmentController) DeleteAttachment(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Spreadsheet ID not found in context",
+		})
+	}
+
+	sheetName, ok := c.Get("sheet_name").(string)
+	if !ok || sheetName == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Sheet name not found in context",
+		})
+	}
+
+	err := h.attachmentUsecase.DeleteAttachment(c.Request().Context(), spreadsheetID, sheetName, c.Param("id"), c.Param("attachment_id"))
+	if err != nil {
+		return c.JSON(attachmentStatus(err), map[string]string{
+			"error": "Failed to delete attachment: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Attachment deleted successfully",
+	})
+}
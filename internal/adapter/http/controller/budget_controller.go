@@ -0,0 +1,309 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"byeboros-backend/internal/adapter/http/model/request"
+	"byeboros-backend/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+// BudgetController handles budget tracking HTTP endpoints
+type BudgetController struct {
+	budgetUsecase *usecase.BudgetUsecase
+}
+
+// NewBudgetController creates a new BudgetController
+func NewBudgetController(budgetUsecase *usecase.BudgetUsecase) *BudgetController {
+	return &BudgetController{budgetUsecase: budgetUsecase}
+}
+
+// GetBudgetStatus handles GET /api/budget/status
+func (h *BudgetController) GetBudgetStatus(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Spreadsheet ID not found in context",
+		})
+	}
+
+	sheetName, ok := c.Get("sheet_name").(string)
+	if !ok || sheetName == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Sheet name not found in context",
+		})
+	}
+
+	data, err := h.budgetUsecase.GetBudgetStatus(spreadsheetID, sheetName)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to fetch budget status: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   data,
+	})
+}
+
+// GetBudgetForecast handles GET /api/budget/forecast
+func (h *BudgetController) GetBudgetForecast(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Spreadsheet ID not found in context",
+		})
+	}
+
+	sheetName, ok := c.Get("sheet_name").(string)
+	if !ok || sheetName == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Sheet name not found in context",
+		})
+	}
+
+	data, err := h.budgetUsecase.GetBudgetForecast(spreadsheetID, sheetName)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to fetch budget forecast: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   data,
+	})
+}
+
+// GetDailyBudget handles GET /api/budget/daily
+// Query params: date (yyyy-MM-dd in the tab's month, optional, defaults to today or the
+// last day of a past month)
+func (h *BudgetController) GetDailyBudget(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Spreadsheet ID not found in context",
+		})
+	}
+
+	sheetName, ok := c.Get("sheet_name").(string)
+	if !ok || sheetName == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Sheet name not found in context",
+		})
+	}
+
+	date := c.QueryParam("date")
+	if date != "" {
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "date must be in format yyyy-MM-dd",
+			})
+		}
+	}
+
+	data, err := h.budgetUsecase.GetDailyBudget(spreadsheetID, sheetName, date)
+	if errors.Is(err, usecase.ErrDateOutsideMonth) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to fetch daily budget: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   data,
+	})
+}
+
+// ListBudgetRollover handles GET /api/budget/rollover
+func (h *BudgetController) ListBudgetRollover(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Spreadsheet ID not found in context",
+		})
+	}
+
+	data, err := h.budgetUsecase.GetBudgetRollovers(spreadsheetID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to fetch budget rollover settings: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   data,
+	})
+}
+
+// SaveBudgetRollover handles PUT /api/budget/rollover
+func (h *BudgetController) SaveBudgetRollover(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Spreadsheet ID not found in context",
+		})
+	}
+
+	var req request.BudgetRolloverRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request payload: " + err.Error(),
+		})
+	}
+
+	// Basic validation
+	if req.CategoryName == "" || req.SubCategoryName == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "category_name and sub_category_name are required",
+		})
+	}
+
+	switch req.Mode {
+	case "none", "surplus", "surplus_and_deficit":
+	default:
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "mode must be one of: none, surplus, surplus_and_deficit",
+		})
+	}
+
+	if err := h.budgetUsecase.SaveBudgetRollover(spreadsheetID, req); err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to save budget rollover: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Budget rollover saved successfully",
+		"data":    req,
+	})
+}
+
+// ListBudgetTemplate handles GET /api/budget/templates
+func (h *BudgetController) ListBudgetTemplate(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Spreadsheet ID not found in context",
+		})
+	}
+
+	data, err := h.budgetUsecase.GetBudgetTemplates(spreadsheetID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to fetch budget templates: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   data,
+	})
+}
+
+// SaveBudgetTemplate handles POST /api/budget/templates
+func (h *BudgetController) SaveBudgetTemplate(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Spreadsheet ID not found in context",
+		})
+	}
+
+	var req request.SaveBudgetTemplateRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request payload: " + err.Error(),
+		})
+	}
+
+	// Basic validation
+	if req.Name == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "name is required",
+		})
+	}
+	if req.SourceSheet == "" && len(req.Categories) == 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "either source_sheet or categories is required",
+		})
+	}
+	for _, cat := range req.Categories {
+		if cat.CategoryName == "" || cat.SubCategoryName == "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "category_name and sub_category_name are required for every category",
+			})
+		}
+	}
+
+	data, err := h.budgetUsecase.SaveBudgetTemplate(spreadsheetID, req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to save budget template: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Budget template saved successfully",
+		"data":    data,
+	})
+}
+
+// ApplyBudget handles POST /api/budget/apply
+func (h *BudgetController) ApplyBudget(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Spreadsheet ID not found in context",
+		})
+	}
+
+	sheetName, ok := c.Get("sheet_name").(string)
+	if !ok || sheetName == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Sheet name not found in context",
+		})
+	}
+
+	var req request.ApplyBudgetRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request payload: " + err.Error(),
+		})
+	}
+
+	// Basic validation
+	if req.ScalePercent < 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "scale_percent cannot be negative",
+		})
+	}
+
+	targetSheet := sheetName
+	if req.TargetSheet != "" {
+		targetSheet = req.TargetSheet
+	}
+
+	data, err := h.budgetUsecase.ApplyBudget(spreadsheetID, targetSheet, req)
+	if errors.Is(err, usecase.ErrMonthHasTransactions) || errors.Is(err, usecase.ErrNotMonthTab) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to apply budget: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Budget applied successfully to " + targetSheet,
+		"data":    data,
+	})
+}
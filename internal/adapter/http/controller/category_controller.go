@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
 
 	"byeboros-backend/internal/adapter/http/model/request"
@@ -32,7 +33,11 @@ func (h *CategoryController) ListCategory(c echo.Context) error {
 		})
 	}
 
-	data, err := h.categoryUsecase.GetCategory(spreadsheetID, sheetName)
+	// month is the tab whose rollover carry-over is reported, defaulting to the current month
+	data, err := h.categoryUsecase.GetCategory(spreadsheetID, sheetName, c.QueryParam("month"))
+	if errors.Is(err, usecase.ErrNotMonthTab) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Failed to fetch categories: " + err.Error(),
@@ -94,3 +99,197 @@ func (h *CategoryController) ListIncomeCategory(c echo.Context) error {
 		"categories": categories,
 	})
 }
+
+func (h *CategoryController) AddCategoryItem(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Spreadsheet ID not found in context"})
+	}
+
+	sheetName, ok := c.Get("sheet_name").(string)
+	if !ok || sheetName == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Sheet name not found in context"})
+	}
+
+	var req request.CategoryItem
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request payload"})
+	}
+
+	if req.CategoryName == "" || req.SubCategoryName == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "category_name and sub_category_name are required"})
+	}
+	if req.Budget < 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "budget cannot be negative"})
+	}
+
+	if err := h.categoryUsecase.AddCategoryItem(spreadsheetID, sheetName, req); err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"message": "Category added successfully",
+		"data":    req,
+	})
+}
+
+func (h *CategoryController) UpdateCategoryItem(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Spreadsheet ID not found in context"})
+	}
+
+	sheetName, ok := c.Get("sheet_name").(string)
+	if !ok || sheetName == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Sheet name not found in context"})
+	}
+
+	var req request.UpdateCategoryItemRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request payload"})
+	}
+
+	if req.CategoryName == "" && req.SubCategoryName == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "category_name or sub_category_name is required"})
+	}
+	if req.NewCategoryName == "" && req.NewSubCategoryName == "" && req.Budget == nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "nothing to update: set new_category_name, new_sub_category_name or budget"})
+	}
+	if req.SubCategoryName == "" && (req.NewSubCategoryName != "" || req.Budget != nil) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "sub_category_name is required to rename or re-budget a sub-category"})
+	}
+	if req.Budget != nil && *req.Budget < 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "budget cannot be negative"})
+	}
+
+	renamed, err := h.categoryUsecase.UpdateCategoryItem(spreadsheetID, sheetName, req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":              "Category updated successfully",
+		"renamed_transactions": renamed,
+	})
+}
+
+func (h *CategoryController) DeleteCategoryItem(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Spreadsheet ID not found in context"})
+	}
+
+	sheetName, ok := c.Get("sheet_name").(string)
+	if !ok || sheetName == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Sheet name not found in context"})
+	}
+
+	categoryName := c.QueryParam("category_name")
+	subCategoryName := c.QueryParam("sub_category_name")
+	if categoryName == "" && subCategoryName == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "category_name or sub_category_name is required"})
+	}
+
+	if err := h.categoryUsecase.DeleteCategoryItem(spreadsheetID, sheetName, categoryName, subCategoryName); err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Category deleted successfully",
+	})
+}
+
+func (h *CategoryController) AddIncomeCategory(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Spreadsheet ID not found in context"})
+	}
+
+	var req request.IncomeCategoryRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request payload"})
+	}
+
+	if req.Name == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "name is required"})
+	}
+
+	if err := h.categoryUsecase.AddIncomeCategory(spreadsheetID, req.Name); err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"message": "Income category added successfully",
+		"data":    req,
+	})
+}
+
+func (h *CategoryController) RenameIncomeCategory(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Spreadsheet ID not found in context"})
+	}
+
+	var req request.IncomeCategoryRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request payload"})
+	}
+
+	if req.Name == "" || req.NewName == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "name and new_name are required"})
+	}
+
+	renamed, err := h.categoryUsecase.RenameIncomeCategory(spreadsheetID, req.Name, req.NewName)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":              "Income category renamed successfully",
+		"renamed_transactions": renamed,
+	})
+}
+
+func (h *CategoryController) DeleteIncomeCategory(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Spreadsheet ID not found in context"})
+	}
+
+	name := c.QueryParam("name")
+	if name == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "name is required"})
+	}
+
+	if err := h.categoryUsecase.DeleteIncomeCategory(spreadsheetID, name); err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Income category deleted successfully",
+	})
+}
+
+func (h *CategoryController) ListUnknownCategoryTransaction(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Spreadsheet ID not found in context"})
+	}
+
+	sheetName, ok := c.Get("sheet_name").(string)
+	if !ok || sheetName == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Sheet name not found in context"})
+	}
+
+	data, err := h.categoryUsecase.GetUnknownCategoryTransactions(spreadsheetID, sheetName)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to fetch transactions with unknown categories: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   data,
+	})
+}
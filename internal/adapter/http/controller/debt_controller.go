@@ -0,0 +1,150 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"byeboros-backend/internal/adapter/http/model/request"
+	"byeboros-backend/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+// DebtController handles debt (utang) and receivable (piutang) HTTP endpoints
+type DebtController struct {
+	debtUsecase *usecase.DebtUsecase
+}
+
+// NewDebtController creates a new DebtController
+func NewDebtController(debtUsecase *usecase.DebtUsecase) *DebtController {
+	return &DebtController{debtUsecase: debtUsecase}
+}
+
+// ListDebt handles GET /api/debts
+func (h *DebtController) ListDebt(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Spreadsheet ID not found in context",
+		})
+	}
+
+	data, err := h.debtUsecase.GetDebts(spreadsheetID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to fetch debts: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   data,
+	})
+}
+
+// CreateDebt handles POST /api/debts
+func (h *DebtController) CreateDebt(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Spreadsheet ID not found in context",
+		})
+	}
+
+	var req request.CreateDebtRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request payload: " + err.Error(),
+		})
+	}
+
+	// Basic validation
+	switch req.Type {
+	case "debt", "receivable", "utang", "hutang", "piutang":
+	default:
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "type must be one of: debt, receivable (or utang, piutang)",
+		})
+	}
+	if req.Counterparty == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "counterparty is required",
+		})
+	}
+	if req.Principal <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "principal must be greater than 0",
+		})
+	}
+	if req.Installments < 0 || req.InstallmentAmount < 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "installments and installment_amount cannot be negative",
+		})
+	}
+	if _, err := time.Parse("2006-01-02", req.FirstDueDate); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "first_due_date must be in format yyyy-MM-dd",
+		})
+	}
+
+	data, err := h.debtUsecase.CreateDebt(spreadsheetID, req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to create debt: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"message": "Debt created successfully",
+		"data":    data,
+	})
+}
+
+// AddPayment handles POST /api/debts/:id/payments
+func (h *DebtController) AddPayment(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Spreadsheet ID not found in context",
+		})
+	}
+
+	sheetName, ok := c.Get("sheet_name").(string)
+	if !ok || sheetName == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Sheet name not found in context",
+		})
+	}
+
+	// Extract email from JWT token (set by JWTMiddleware)
+	createdBy, _ := c.Get("email").(string)
+
+	var req request.DebtPaymentRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request payload: " + err.Error(),
+		})
+	}
+
+	// Basic validation
+	if req.TransactionID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "transaction_id is required",
+		})
+	}
+	if req.Amount < 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "amount cannot be negative",
+		})
+	}
+
+	if err := h.debtUsecase.AddPayment(spreadsheetID, sheetName, c.Param("id"), req, createdBy); err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to add debt payment: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"message": "Debt payment added successfully",
+	})
+}
@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"net/http"
+
+	"byeboros-backend/internal/adapter/http/model/request"
+	"byeboros-backend/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ExchangeRateController handles exchange-rate HTTP endpoints
+type ExchangeRateController struct {
+	exchangeRateUsecase *usecase.ExchangeRateUsecase
+}
+
+// NewExchangeRateController creates a new ExchangeRateController
+func NewExchangeRateController(exchangeRateUsecase *usecase.ExchangeRateUsecase) *ExchangeRateController {
+	return &ExchangeRateController{exchangeRateUsecase: exchangeRateUsecase}
+}
+
+// ListExchangeRate handles GET /api/exchange-rates
+func (h *ExchangeRateController) ListExchangeRate(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Spreadsheet ID not found in context",
+		})
+	}
+
+	data, err := h.exchangeRateUsecase.GetExchangeRates(spreadsheetID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to fetch exchange rates: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   data,
+	})
+}
+
+// SaveExchangeRate handles POST /api/exchange-rates
+func (h *ExchangeRateController) SaveExchangeRate(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Spreadsheet ID not found in context",
+		})
+	}
+
+	var req request.ExchangeRateRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request payload: " + err.Error(),
+		})
+	}
+
+	if err := req.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	if err := h.exchangeRateUsecase.SaveExchangeRate(spreadsheetID, req); err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to save exchange rate: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"message": "Exchange rate saved successfully",
+		"data":    req,
+	})
+}
+
+// ImportExchangeRate handles POST /api/exchange-rates/import with a CSV "file" form field
+// (columns: currency,rate,effective_date)
+func (h *ExchangeRateController) ImportExchangeRate(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Spreadsheet ID not found in context",
+		})
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "file is required",
+		})
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Failed to open file: " + err.Error(),
+		})
+	}
+	defer file.Close()
+
+	count, err := h.exchangeRateUsecase.ImportExchangeRates(spreadsheetID, file)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Failed to import exchange rates: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"message":  "Exchange rates imported successfully",
+		"imported": count,
+	})
+}
@@ -0,0 +1,147 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"byeboros-backend/internal/adapter/http/model/request"
+	"byeboros-backend/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GoalController handles savings goal HTTP endpoints
+type GoalController struct {
+	goalUsecase *usecase.GoalUsecase
+}
+
+// NewGoalController creates a new GoalController
+func NewGoalController(goalUsecase *usecase.GoalUsecase) *GoalController {
+	return &GoalController{goalUsecase: goalUsecase}
+}
+
+// ListGoal handles GET /api/goals
+func (h *GoalController) ListGoal(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Spreadsheet ID not found in context",
+		})
+	}
+
+	data, err := h.goalUsecase.GetGoals(spreadsheetID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to fetch goals: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   data,
+	})
+}
+
+// CreateGoal handles POST /api/goals
+func (h *GoalController) CreateGoal(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Spreadsheet ID not found in context",
+		})
+	}
+
+	var req request.CreateGoalRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request payload: " + err.Error(),
+		})
+	}
+
+	// Basic validation
+	if req.Name == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "name is required",
+		})
+	}
+	if req.TargetAmount <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "target_amount must be greater than 0",
+		})
+	}
+	if _, err := time.Parse("2006-01-02", req.Deadline); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "deadline must be in format yyyy-MM-dd",
+		})
+	}
+
+	data, err := h.goalUsecase.CreateGoal(spreadsheetID, req)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to create goal: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"message": "Goal created successfully",
+		"data":    data,
+	})
+}
+
+// AddContribution handles POST /api/goals/:id/contributions
+func (h *GoalController) AddContribution(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Spreadsheet ID not found in context",
+		})
+	}
+
+	sheetName, ok := c.Get("sheet_name").(string)
+	if !ok || sheetName == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Sheet name not found in context",
+		})
+	}
+
+	var req request.GoalContributionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request payload: " + err.Error(),
+		})
+	}
+
+	// Basic validation
+	if req.Amount <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "amount must be greater than 0",
+		})
+	}
+	if req.SourceType != "income" && req.SourceType != "transfer" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "source_type must be one of: income, transfer",
+		})
+	}
+	if req.SourceID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "source_id is required",
+		})
+	}
+	if req.ContributedAt != "" {
+		if _, err := time.Parse("2006-01-02", req.ContributedAt); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "contributed_at must be in format yyyy-MM-dd",
+			})
+		}
+	}
+
+	if err := h.goalUsecase.AddContribution(spreadsheetID, sheetName, c.Param("id"), req); err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to add goal contribution: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"message": "Goal contribution added successfully",
+	})
+}
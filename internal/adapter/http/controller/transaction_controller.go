@@ -1,10 +1,14 @@
 package controller
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"byeboros-backend/internal/adapter/http/model/request"
+	"byeboros-backend/internal/adapter/http/model/response"
 	"byeboros-backend/internal/usecase"
 
 	"github.com/labstack/echo/v4"
@@ -56,6 +60,12 @@ func (h *TransactionController) AddIncomeTransaction(c echo.Context) error {
 		})
 	}
 
+	if err := request.ValidateCurrency(req.Currency); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
 	if req.Amount <= 0 {
 		return c.JSON(http.StatusBadRequest, map[string]string{
 			"error": "amount must be greater than 0",
@@ -66,6 +76,11 @@ func (h *TransactionController) AddIncomeTransaction(c echo.Context) error {
 	createdBy, _ := c.Get("email").(string)
 
 	if err := h.transactionUsecase.AddIncomeTransaction(spreadsheetID, sheetName, req, createdBy); err != nil {
+		if errors.Is(err, usecase.ErrUnknownCategory) {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Failed to add income transaction: " + err.Error(),
 		})
@@ -99,8 +114,8 @@ func (h *TransactionController) AddExpenseTransaction(c echo.Context) error {
 		})
 	}
 
-	// Basic validation
-	if req.Description == "" || req.Category == "" || req.TransactionAt == "" {
+	// Basic validation (category comes from the splits when the expense is split)
+	if req.Description == "" || (req.Category == "" && len(req.Splits) == 0) || req.TransactionAt == "" {
 		return c.JSON(http.StatusBadRequest, map[string]string{
 			"error": "description, category, and transaction_at are required",
 		})
@@ -112,16 +127,33 @@ func (h *TransactionController) AddExpenseTransaction(c echo.Context) error {
 		})
 	}
 
+	if err := request.ValidateCurrency(req.Currency); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
 	if req.Amount <= 0 {
 		return c.JSON(http.StatusBadRequest, map[string]string{
 			"error": "amount must be greater than 0",
 		})
 	}
 
+	if err := request.ValidateSplits(req.Amount, req.Splits); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
 	// Extract email from JWT token (set by JWTMiddleware)
 	createdBy, _ := c.Get("email").(string)
 
 	if err := h.transactionUsecase.AddExpenseTransaction(spreadsheetID, sheetName, req, createdBy); err != nil {
+		if errors.Is(err, usecase.ErrUnknownCategory) {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Failed to add expense transaction: " + err.Error(),
 		})
@@ -132,6 +164,62 @@ func (h *TransactionController) AddExpenseTransaction(c echo.Context) error {
 	})
 }
 
+// AddTransferTransaction handles POST /api/transaction/transfer
+func (h *TransactionController) AddTransferTransaction(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Spreadsheet ID not found in context",
+		})
+	}
+
+	sheetName, ok := c.Get("sheet_name").(string)
+	if !ok || sheetName == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Sheet name not found in context",
+		})
+	}
+
+	var req request.TransferTransactionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request payload: " + err.Error(),
+		})
+	}
+
+	// Basic validation
+	if req.Description == "" || req.FromAccountID == "" || req.ToAccountID == "" || req.TransactionAt == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "description, from_account_id, to_account_id, and transaction_at are required",
+		})
+	}
+
+	if err := request.ValidateTransactionAt(req.TransactionAt); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	if req.Amount <= 0 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "amount must be greater than 0",
+		})
+	}
+
+	// Extract email from JWT token (set by JWTMiddleware)
+	createdBy, _ := c.Get("email").(string)
+
+	if err := h.transactionUsecase.AddTransferTransaction(spreadsheetID, sheetName, req, createdBy); err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to add transfer: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"message": "Transfer added successfully",
+	})
+}
+
 // UpdateTransaction handles PUT /api/transaction
 func (h *TransactionController) UpdateTransaction(c echo.Context) error {
 	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
@@ -168,7 +256,7 @@ func (h *TransactionController) UpdateTransaction(c echo.Context) error {
 		})
 	}
 
-	if req.Description == "" || req.Category == "" || req.TransactionAt == "" {
+	if req.Description == "" || (req.Category == "" && len(req.Splits) == 0) || req.TransactionAt == "" {
 		return c.JSON(http.StatusBadRequest, map[string]string{
 			"error": "description, category, and transaction_at are required",
 		})
@@ -180,16 +268,39 @@ func (h *TransactionController) UpdateTransaction(c echo.Context) error {
 		})
 	}
 
+	if err := request.ValidateCurrency(req.Currency); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
 	if req.Amount <= 0 {
 		return c.JSON(http.StatusBadRequest, map[string]string{
 			"error": "amount must be greater than 0",
 		})
 	}
 
+	if len(req.Splits) > 0 && req.Type != "expense" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "splits are only supported for expense transactions",
+		})
+	}
+
+	if err := request.ValidateSplits(req.Amount, req.Splits); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
 	// Extract email from JWT token (set by JWTMiddleware)
 	updatedBy, _ := c.Get("email").(string)
 
-	if err := h.transactionUsecase.UpdateTransaction(spreadsheetID, sheetName, req, updatedBy); err != nil {
+	if err := h.transactionUsecase.UpdateTransaction(c.Request().Context(), spreadsheetID, sheetName, req, updatedBy); err != nil {
+		if errors.Is(err, usecase.ErrUnknownCategory) || errors.Is(err, usecase.ErrSplitRequired) || errors.Is(err, usecase.ErrTransactionType) {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Failed to update transaction: " + err.Error(),
 		})
@@ -200,6 +311,40 @@ func (h *TransactionController) UpdateTransaction(c echo.Context) error {
 	})
 }
 
+// DeleteTransaction handles DELETE /api/transaction/:id
+func (h *TransactionController) DeleteTransaction(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Spreadsheet ID not found in context",
+		})
+	}
+
+	sheetName, ok := c.Get("sheet_name").(string)
+	if !ok || sheetName == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Sheet name not found in context",
+		})
+	}
+
+	id := c.Param("id")
+	if id == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "id is required",
+		})
+	}
+
+	if err := h.transactionUsecase.DeleteTransaction(c.Request().Context(), spreadsheetID, sheetName, id); err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to delete transaction: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Transaction deleted successfully",
+	})
+}
+
 // ListTransaction returns the list of transactions with optional date and category filters
 func (h *TransactionController) ListTransaction(c echo.Context) error {
 	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
@@ -218,8 +363,10 @@ func (h *TransactionController) ListTransaction(c echo.Context) error {
 	dateFilter := c.QueryParam("date")
 	categoryFilter := c.QueryParam("category")
 	typeFilter := c.QueryParam("type")
+	tagFilter := c.QueryParam("tag")
+	createdByFilter := c.QueryParam("created_by")
 
-	data, err := h.transactionUsecase.GetListTransaction(spreadsheetID, sheetName, dateFilter, categoryFilter, typeFilter)
+	data, err := h.transactionUsecase.GetListTransaction(spreadsheetID, sheetName, dateFilter, categoryFilter, typeFilter, tagFilter, createdByFilter)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": "Failed to fetch transactions: " + err.Error(),
@@ -233,6 +380,8 @@ func (h *TransactionController) ListTransaction(c echo.Context) error {
 }
 
 // GetAnalysis fetches the financial analysis data with optional period filter
+// Query params: period, date (period=Day only), or from and to (yyyy-MM-dd) for a custom range;
+// compare=true adds the previous period
 func (h *TransactionController) GetAnalysis(c echo.Context) error {
 	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
 	if !ok || spreadsheetID == "" {
@@ -248,48 +397,413 @@ func (h *TransactionController) GetAnalysis(c echo.Context) error {
 		})
 	}
 
-	// Get period query parameter (default: "Month")
+	// An explicit from/to range replaces the period filter
+	from := c.QueryParam("from")
+	to := c.QueryParam("to")
 	period := c.QueryParam("period")
-	if period == "" {
-		period = "Month"
+	date := c.QueryParam("date")
+	if from != "" || to != "" {
+		if date != "" {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "date param cannot be combined with from/to",
+			})
+		}
+		if err := validateDateRange(from, to); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		period = usecase.AnalysisPeriodCustom
+	} else {
+		// Get period query parameter (default: "Month")
+		if period == "" {
+			period = "Month"
+		}
+
+		// Validate period
+		validPeriods := []string{"Day", "Month", "3 Months", "6 Months", "Year"}
+		isValid := false
+		for _, vp := range validPeriods {
+			if period == vp {
+				isValid = true
+				break
+			}
+		}
+		if !isValid {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": "Invalid period. Must be one of: Day, Month, 3 Months, 6 Months, Year",
+			})
+		}
+
+		// Get optional date param (only applicable when period=Day)
+		if date != "" {
+			if period != "Day" {
+				return c.JSON(http.StatusBadRequest, map[string]string{
+					"error": "date param is only supported when period=Day",
+				})
+			}
+			if _, err := time.Parse("2006-01-02", date); err != nil {
+				return c.JSON(http.StatusBadRequest, map[string]string{
+					"error": "date must be in YYYY-MM-DD format (e.g. 2026-03-01)",
+				})
+			}
+		}
 	}
 
-	// Validate period
-	validPeriods := []string{"Day", "Month", "3 Months", "6 Months", "Year"}
-	isValid := false
-	for _, vp := range validPeriods {
-		if period == vp {
-			isValid = true
-			break
+	var data *response.AnalysisResponse
+	var err error
+	if period == usecase.AnalysisPeriodCustom {
+		data, err = h.transactionUsecase.GetRangeAnalysis(spreadsheetID, from, to)
+	} else {
+		data, err = h.transactionUsecase.GetAnalysis(spreadsheetID, sheetName, period, date)
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to fetch analysis: " + err.Error(),
+		})
+	}
+
+	// Optionally compare with the previous period of the same length
+	if c.QueryParam("compare") == "true" {
+		if err := h.transactionUsecase.AddAnalysisComparison(spreadsheetID, sheetName, period, date, from, to, data); err != nil {
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": "Failed to compare with the previous period: " + err.Error(),
+			})
 		}
 	}
-	if !isValid {
+
+	return c.JSON(http.StatusOK, data)
+}
+
+// GetTagAnalysis handles GET /api/analysis/tags
+// Query params: from, to (yyyy-MM-dd, default to the current month up to today)
+func (h *TransactionController) GetTagAnalysis(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Spreadsheet ID not found in context",
+		})
+	}
+
+	from := c.QueryParam("from")
+	to := c.QueryParam("to")
+	if err := validateDateRange(from, to); err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{
-			"error": "Invalid period. Must be one of: Day, Month, 3 Months, 6 Months, Year",
+			"error": err.Error(),
 		})
 	}
 
-	// Get optional date param (only applicable when period=Day)
-	date := c.QueryParam("date")
-	if date != "" {
-		if period != "Day" {
+	data, err := h.transactionUsecase.GetTagAnalysis(spreadsheetID, from, to)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to fetch tag analysis: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   data,
+	})
+}
+
+// GetTrendAnalysis handles GET /api/analysis/trend
+// Query params: from, to (yyyy-MM-dd), interval (day, week or month, default day),
+// by_category (true to add a series per category)
+func (h *TransactionController) GetTrendAnalysis(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Spreadsheet ID not found in context",
+		})
+	}
+
+	from := c.QueryParam("from")
+	to := c.QueryParam("to")
+	if err := validateDateRange(from, to); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	interval := c.QueryParam("interval")
+	if interval != "" && interval != "day" && interval != "week" && interval != "month" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid interval. Must be one of: day, week, month",
+		})
+	}
+	byCategory := c.QueryParam("by_category") == "true"
+
+	data, err := h.transactionUsecase.GetTrendAnalysis(spreadsheetID, from, to, interval, byCategory)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to fetch trend analysis: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   data,
+	})
+}
+
+// GetHeatmapAnalysis handles GET /api/analysis/heatmap
+// Query params: from, to (yyyy-MM-dd), category (optional sub-category filter),
+// by_category (true to add a grid per sub-category)
+func (h *TransactionController) GetHeatmapAnalysis(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Spreadsheet ID not found in context",
+		})
+	}
+
+	from := c.QueryParam("from")
+	to := c.QueryParam("to")
+	if err := validateDateRange(from, to); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	category := c.QueryParam("category")
+	byCategory := c.QueryParam("by_category") == "true"
+
+	data, err := h.transactionUsecase.GetHeatmapAnalysis(spreadsheetID, from, to, category, byCategory)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to fetch heatmap analysis: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   data,
+	})
+}
+
+// GetMemberAnalysis handles GET /api/analysis/members
+// Query params: from, to (yyyy-MM-dd, default to the current month up to today)
+func (h *TransactionController) GetMemberAnalysis(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Spreadsheet ID not found in context",
+		})
+	}
+
+	from := c.QueryParam("from")
+	to := c.QueryParam("to")
+	if err := validateDateRange(from, to); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	data, err := h.transactionUsecase.GetMemberAnalysis(spreadsheetID, from, to)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to fetch member analysis: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   data,
+	})
+}
+
+// GetYearlyAnalysis handles GET /api/analysis/yearly
+// Query params: year (optional, defaults to the year of the spreadsheet)
+func (h *TransactionController) GetYearlyAnalysis(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Spreadsheet ID not found in context",
+		})
+	}
+
+	var year int
+	if yearStr := c.QueryParam("year"); yearStr != "" {
+		parsed, err := strconv.Atoi(yearStr)
+		if err != nil || parsed < 2000 || parsed > 2100 {
 			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": "date param is only supported when period=Day",
+				"error": "year must be a number between 2000 and 2100",
 			})
 		}
-		if _, err := time.Parse("2006-01-02", date); err != nil {
+		year = parsed
+	}
+
+	data, err := h.transactionUsecase.GetYearlyAnalysis(spreadsheetID, year)
+	if errors.Is(err, usecase.ErrYearNotLinked) {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": err.Error(),
+		})
+	}
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to fetch yearly analysis: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   data,
+	})
+}
+
+// GetPriorityAnalysis handles GET /api/analysis/priority
+// Query params: from, to (yyyy-MM-dd), interval (day, week or month, default month),
+// cut_percent (0-100, default 20) for the low-priority "what if" savings
+func (h *TransactionController) GetPriorityAnalysis(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Spreadsheet ID not found in context",
+		})
+	}
+
+	from := c.QueryParam("from")
+	to := c.QueryParam("to")
+	if err := validateDateRange(from, to); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	interval := c.QueryParam("interval")
+	if interval != "" && interval != "day" && interval != "week" && interval != "month" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid interval. Must be one of: day, week, month",
+		})
+	}
+
+	cutPercent := 20.0
+	if cutStr := c.QueryParam("cut_percent"); cutStr != "" {
+		parsed, err := strconv.ParseFloat(cutStr, 64)
+		if err != nil || parsed < 0 || parsed > 100 {
 			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": "date must be in YYYY-MM-DD format (e.g. 2026-03-01)",
+				"error": "cut_percent must be a number between 0 and 100",
 			})
 		}
+		cutPercent = parsed
 	}
 
-	data, err := h.transactionUsecase.GetAnalysis(spreadsheetID, sheetName, period, date)
+	data, err := h.transactionUsecase.GetPriorityAnalysis(spreadsheetID, from, to, interval, cutPercent)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": "Failed to fetch analysis: " + err.Error(),
+			"error": "Failed to fetch priority analysis: " + err.Error(),
 		})
 	}
 
-	return c.JSON(http.StatusOK, data)
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   data,
+	})
+}
+
+// GetAnomalies handles GET /api/insights/anomalies
+// Query params: from, to (yyyy-MM-dd, default to the current month up to today)
+func (h *TransactionController) GetAnomalies(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Spreadsheet ID not found in context",
+		})
+	}
+
+	from := c.QueryParam("from")
+	to := c.QueryParam("to")
+	if err := validateDateRange(from, to); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	data, err := h.transactionUsecase.GetAnomalies(spreadsheetID, from, to)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to fetch anomalies: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   data,
+	})
+}
+
+// ListYearSpreadsheet handles GET /api/spreadsheets
+func (h *TransactionController) ListYearSpreadsheet(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Spreadsheet ID not found in context",
+		})
+	}
+
+	data, err := h.transactionUsecase.GetYearSpreadsheets(spreadsheetID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to fetch year spreadsheets: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"status": "success",
+		"data":   data,
+	})
+}
+
+// SaveYearSpreadsheet handles PUT /api/spreadsheets
+func (h *TransactionController) SaveYearSpreadsheet(c echo.Context) error {
+	spreadsheetID, ok := c.Get("spreadsheet_id").(string)
+	if !ok || spreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Spreadsheet ID not found in context",
+		})
+	}
+
+	var req request.YearSpreadsheetRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request payload: " + err.Error(),
+		})
+	}
+
+	// Basic validation
+	if req.Year < 2000 || req.Year > 2100 {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "year must be between 2000 and 2100",
+		})
+	}
+	if req.SpreadsheetID == "" {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "spreadsheet_id is required",
+		})
+	}
+
+	if err := h.transactionUsecase.SaveYearSpreadsheet(spreadsheetID, req); err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to save year spreadsheet: " + err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Year spreadsheet saved successfully",
+	})
+}
+
+// validateDateRange checks optional from/to query params in yyyy-MM-dd format, with a
+// missing bound defaulted the way the usecase does it
+func validateDateRange(from, to string) error {
+	for _, param := range [][2]string{{"from", from}, {"to", to}} {
+		if param[1] == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", param[1]); err != nil {
+			return fmt.Errorf("%s must be in YYYY-MM-DD format (e.g. 2026-03-01)", param[0])
+		}
+	}
+	_, _, err := usecase.ParseDateRange(from, to)
+	return err
 }
@@ -0,0 +1,8 @@
+package request
+
+// CreateAccountRequest represents the payload for creating an account or wallet
+type CreateAccountRequest struct {
+	Name           string  `json:"name" validate:"required"`
+	Type           string  `json:"type" validate:"required,oneof=cash bank ewallet credit other"`
+	OpeningBalance float64 `json:"opening_balance"`
+}
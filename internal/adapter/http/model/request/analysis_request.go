@@ -0,0 +1,7 @@
+package request
+
+// YearSpreadsheetRequest links the spreadsheet holding a year's month tabs
+type YearSpreadsheetRequest struct {
+	Year          int    `json:"year" validate:"required"`
+	SpreadsheetID string `json:"spreadsheet_id" validate:"required"`
+}
@@ -0,0 +1,27 @@
+package request
+
+// BudgetRolloverRequest sets how a sub-category's remainder carries into the next month
+type BudgetRolloverRequest struct {
+	CategoryName    string `json:"category_name" validate:"required"`
+	SubCategoryName string `json:"sub_category_name" validate:"required"`
+	Mode            string `json:"mode" validate:"required,oneof=none surplus surplus_and_deficit"`
+}
+
+// SaveBudgetTemplateRequest creates or replaces a named budget template. When source_sheet
+// is set, the template is copied from that month tab instead of the given categories.
+type SaveBudgetTemplateRequest struct {
+	Name          string         `json:"name" validate:"required"`
+	SourceSheet   string         `json:"source_sheet"`
+	DailyBudget   float64        `json:"daily_budget"`
+	MonthlyBudget float64        `json:"monthly_budget"`
+	Categories    []CategoryItem `json:"categories"`
+}
+
+// ApplyBudgetRequest writes a template, or last month's budgets when template is empty,
+// into the target month tab (the X-Sheet-Name tab unless target_sheet is set). The tab must
+// not have expense transactions yet.
+type ApplyBudgetRequest struct {
+	Template     string  `json:"template"`
+	TargetSheet  string  `json:"target_sheet"`
+	ScalePercent float64 `json:"scale_percent"` // e.g. 110 for +10%, defaults to 100
+}
@@ -11,3 +11,19 @@ type SaveCategoryRequest struct {
 	MonthlyBudget float64        `json:"monthly_budget"`
 	Categories    []CategoryItem `json:"categories"`
 }
+
+// UpdateCategoryItemRequest renames and/or re-budgets one sub-category. When
+// sub_category_name is empty, every sub-category of category_name is updated.
+type UpdateCategoryItemRequest struct {
+	CategoryName       string   `json:"category_name"`
+	SubCategoryName    string   `json:"sub_category_name"`
+	NewCategoryName    string   `json:"new_category_name"`
+	NewSubCategoryName string   `json:"new_sub_category_name"`
+	Budget             *float64 `json:"budget"`
+}
+
+// IncomeCategoryRequest adds an income category, or renames one when new_name is set
+type IncomeCategoryRequest struct {
+	Name    string `json:"name" validate:"required"`
+	NewName string `json:"new_name"`
+}
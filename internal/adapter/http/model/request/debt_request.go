@@ -0,0 +1,18 @@
+package request
+
+// CreateDebtRequest represents the payload for a debt (utang) or receivable (piutang)
+type CreateDebtRequest struct {
+	Type              string  `json:"type" validate:"required,oneof=debt receivable utang hutang piutang"`
+	Counterparty      string  `json:"counterparty" validate:"required"`
+	Principal         float64 `json:"principal" validate:"required,gt=0"`
+	Installments      int     `json:"installments"`                       // defaults to 1
+	InstallmentAmount float64 `json:"installment_amount"`                 // defaults to principal / installments
+	FirstDueDate      string  `json:"first_due_date" validate:"required"` // format: yyyy-MM-dd, then monthly
+	Notes             *string `json:"notes"`
+}
+
+// DebtPaymentRequest links an expense (debt) or income (receivable) transaction to a debt
+type DebtPaymentRequest struct {
+	TransactionID string  `json:"transaction_id" validate:"required"`
+	Amount        float64 `json:"amount"` // defaults to the transaction amount
+}
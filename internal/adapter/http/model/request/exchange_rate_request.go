@@ -0,0 +1,43 @@
+package request
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var currencyCodePattern = regexp.MustCompile(`^[A-Za-z]{3}$`)
+
+// ValidateCurrency validates an optional ISO 4217 currency code (e.g. IDR, USD)
+func ValidateCurrency(currency string) error {
+	if currency != "" && !currencyCodePattern.MatchString(strings.TrimSpace(currency)) {
+		return fmt.Errorf("currency must be a 3-letter ISO 4217 code (e.g. USD)")
+	}
+	return nil
+}
+
+// ExchangeRateRequest represents a manually entered exchange rate.
+// Rate is the value of one unit of Currency in the base currency.
+type ExchangeRateRequest struct {
+	Currency      string  `json:"currency" validate:"required"`
+	Rate          float64 `json:"rate" validate:"required"`
+	EffectiveDate string  `json:"effective_date" validate:"required"`
+}
+
+// Validate checks the currency code, rate and effective date (YYYY-MM-DD)
+func (r *ExchangeRateRequest) Validate() error {
+	if r.Currency == "" {
+		return fmt.Errorf("currency is required")
+	}
+	if err := ValidateCurrency(r.Currency); err != nil {
+		return err
+	}
+	if r.Rate <= 0 {
+		return fmt.Errorf("rate must be greater than 0")
+	}
+	if _, err := time.Parse("2006-01-02", r.EffectiveDate); err != nil {
+		return fmt.Errorf("effective_date must be in YYYY-MM-DD format (e.g. 2026-03-01)")
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package request
+
+// CreateGoalRequest represents the payload for creating a savings goal
+type CreateGoalRequest struct {
+	Name         string  `json:"name" validate:"required"`
+	TargetAmount float64 `json:"target_amount" validate:"required,gt=0"`
+	Deadline     string  `json:"deadline" validate:"required"` // format: yyyy-MM-dd
+}
+
+// GoalContributionRequest allocates money from an income transaction or a transfer to a goal
+type GoalContributionRequest struct {
+	Amount        float64 `json:"amount" validate:"required,gt=0"`
+	SourceType    string  `json:"source_type" validate:"required,oneof=income transfer"`
+	SourceID      string  `json:"source_id" validate:"required"`
+	ContributedAt string  `json:"contributed_at"` // format: yyyy-MM-dd, defaults to the source date
+	Notes         *string `json:"notes"`
+}
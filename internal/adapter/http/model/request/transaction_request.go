@@ -2,6 +2,7 @@ package request
 
 import (
 	"fmt"
+	"math"
 	"time"
 )
 
@@ -15,32 +16,94 @@ func ValidateTransactionAt(transactionAt string) error {
 	return nil
 }
 
+// ValidateSplits validates that every split has a category and a positive amount,
+// and that the split amounts add up to the transaction total.
+func ValidateSplits(total float64, splits []ExpenseSplitRequest) error {
+	if len(splits) == 0 {
+		return nil
+	}
+	if len(splits) == 1 {
+		return fmt.Errorf("splits must contain at least 2 items")
+	}
+
+	var sum float64
+	for i, split := range splits {
+		if split.Category == "" {
+			return fmt.Errorf("splits[%d].category is required", i)
+		}
+		if split.Amount <= 0 {
+			return fmt.Errorf("splits[%d].amount must be greater than 0", i)
+		}
+		sum += split.Amount
+	}
+
+	if math.Abs(sum-total) > 0.005 {
+		return fmt.Errorf("splits must sum to amount (got %.2f, expected %.2f)", sum, total)
+	}
+	return nil
+}
+
 // IncomeTransactionRequest represents the payload for adding an income transaction
 type IncomeTransactionRequest struct {
-	Description   string  `json:"description" validate:"required"`
-	Category      string  `json:"category" validate:"required"`
-	Amount        float64 `json:"amount" validate:"required"`
-	Notes         *string `json:"notes"`
-	TransactionAt string  `json:"transaction_at" validate:"required"`
+	Description   string   `json:"description" validate:"required"`
+	Category      string   `json:"category" validate:"required"`
+	Amount        float64  `json:"amount" validate:"required"`
+	Notes         *string  `json:"notes"`
+	TransactionAt string   `json:"transaction_at" validate:"required"`
+	AccountID     string   `json:"account_id"`
+	Currency      string   `json:"currency"` // ISO 4217 code of amount, defaults to the base currency
+	Tags          []string `json:"tags"`
 }
 
 // ExpenseTransactionRequest represents the payload for adding an expense transaction
 type ExpenseTransactionRequest struct {
-	Description   string  `json:"description" validate:"required"`
-	Category      string  `json:"category" validate:"required"`
-	Priority      string  `json:"priority"`
-	Amount        float64 `json:"amount" validate:"required"`
-	Notes         *string `json:"notes"`
-	TransactionAt string  `json:"transaction_at" validate:"required"`
+	Description   string                `json:"description" validate:"required"`
+	Category      string                `json:"category" validate:"required_without=Splits"`
+	Priority      string                `json:"priority"`
+	Amount        float64               `json:"amount" validate:"required"`
+	Notes         *string               `json:"notes"`
+	TransactionAt string                `json:"transaction_at" validate:"required"`
+	Splits        []ExpenseSplitRequest `json:"splits"`
+	AccountID     string                `json:"account_id"`
+	Currency      string                `json:"currency"` // ISO 4217 code of amount and splits, defaults to the base currency
+	Tags          []string              `json:"tags"`
+
+	// AllowNewCategory accepts an expense category missing from the master data category table
+	AllowNewCategory bool `json:"allow_new_category"`
+}
+
+// ExpenseSplitRequest represents one line of an expense split across sub-categories.
+// Priority falls back to the parent transaction's priority when empty.
+type ExpenseSplitRequest struct {
+	Category string  `json:"category" validate:"required"`
+	Priority string  `json:"priority"`
+	Amount   float64 `json:"amount" validate:"required"`
 }
 
 // UpdateTransactionRequest represents the payload for updating a transaction
 type UpdateTransactionRequest struct {
-	ID            string  `json:"id" validate:"required"`
-	Type          string  `json:"type" validate:"required,oneof=income expense"`
+	ID            string                `json:"id" validate:"required"`
+	Type          string                `json:"type" validate:"required,oneof=income expense"`
+	Description   string                `json:"description" validate:"required"`
+	Category      string                `json:"category" validate:"required_without=Splits"`
+	Priority      string                `json:"priority"`
+	Amount        float64               `json:"amount" validate:"required"`
+	Notes         *string               `json:"notes"`
+	TransactionAt string                `json:"transaction_at" validate:"required"`
+	Splits        []ExpenseSplitRequest `json:"splits"`
+	AccountID     string                `json:"account_id"`
+	Currency      string                `json:"currency"` // ISO 4217 code of amount and splits, omit to keep the current currency
+	Tags          []string              `json:"tags"`     // omit to keep the current tags
+
+	// AllowNewCategory accepts an expense category missing from the master data category table
+	AllowNewCategory bool `json:"allow_new_category"`
+}
+
+// TransferTransactionRequest represents the payload for moving money between accounts
+type TransferTransactionRequest struct {
 	Description   string  `json:"description" validate:"required"`
-	Category      string  `json:"category" validate:"required"`
-	Priority      string  `json:"priority"`
+	FromAccountID string  `json:"from_account_id" validate:"required"`
+	ToAccountID   string  `json:"to_account_id" validate:"required"`
 	Amount        float64 `json:"amount" validate:"required"`
 	Notes         *string `json:"notes"`
 	TransactionAt string  `json:"transaction_at" validate:"required"`
@@ -0,0 +1,36 @@
+package response
+
+type AccountResponse struct {
+	TotalBalance        float64       `json:"total_balance"`
+	TotalBalanceDisplay string        `json:"total_balance_display"`
+	Accounts            []AccountItem `json:"accounts"`
+}
+
+type AccountItem struct {
+	ID                    string  `json:"id"`
+	Name                  string  `json:"name"`
+	Type                  string  `json:"type"`
+	OpeningBalance        float64 `json:"opening_balance"`
+	OpeningBalanceDisplay string  `json:"opening_balance_display"`
+	TotalIncome           float64 `json:"total_income"`
+	TotalExpense          float64 `json:"total_expense"`
+	TotalTransferIn       float64 `json:"total_transfer_in"`
+	TotalTransferOut      float64 `json:"total_transfer_out"`
+	Balance               float64 `json:"balance"`
+	BalanceDisplay        string  `json:"balance_display"`
+}
+
+type AccountBalanceResponse struct {
+	Account AccountItem         `json:"account"`
+	Days    []AccountBalanceDay `json:"days"`
+}
+
+type AccountBalanceDay struct {
+	Date           string  `json:"date"` // yyyy-MM-dd
+	Income         float64 `json:"income"`
+	Expense        float64 `json:"expense"`
+	TransferIn     float64 `json:"transfer_in"`
+	TransferOut    float64 `json:"transfer_out"`
+	Balance        float64 `json:"balance"`
+	BalanceDisplay string  `json:"balance_display"`
+}
@@ -6,8 +6,14 @@ type AnalysisResponse struct {
 }
 
 type AnalysisData struct {
-	Expense AnalysisExpenseData `json:"expense"`
-	Income  AnalysisIncomeData  `json:"income"`
+	From         string                  `json:"from,omitempty"` // set for date-range analysis
+	To           string                  `json:"to,omitempty"`
+	BaseCurrency string                  `json:"base_currency"`
+	Expense      AnalysisExpenseData     `json:"expense"`
+	Income       AnalysisIncomeData      `json:"income"`
+	Currencies   []AnalysisCurrencyTotal `json:"currencies"`
+	CashFlow     AnalysisCashFlow        `json:"cash_flow"`
+	Comparison   *AnalysisComparison     `json:"comparison,omitempty"` // only with compare=true
 }
 
 type AnalysisExpenseData struct {
@@ -69,3 +75,291 @@ type AnalysisPriorityDistribution struct {
 	Amount        float64 `json:"amount"`
 	AmountDisplay string  `json:"amount_display"`
 }
+
+type AnalysisCurrencyTotal struct {
+	Type                   string  `json:"type"` // "expense" or "income"
+	Currency               string  `json:"currency"`
+	OriginalAmount         float64 `json:"original_amount"`
+	OriginalAmountDisplay  string  `json:"original_amount_display"`
+	ConvertedAmount        float64 `json:"converted_amount"`
+	ConvertedAmountDisplay string  `json:"converted_amount_display"`
+}
+
+type AnalysisCashFlow struct {
+	TotalIncome          float64                `json:"total_income"`
+	TotalExpense         float64                `json:"total_expense"`
+	NetSavings           float64                `json:"net_savings"`
+	NetSavingsDisplay    string                 `json:"net_savings_display"`
+	SavingsRate          float64                `json:"savings_rate"` // percent of income saved, 0 without income
+	LowestBalance        float64                `json:"lowest_balance"`
+	LowestBalanceDisplay string                 `json:"lowest_balance_display"`
+	Balance              []AnalysisBalancePoint `json:"balance"`       // cumulative balance per day
+	NegativeDays         []AnalysisBalancePoint `json:"negative_days"` // days ending with a negative balance
+}
+
+type AnalysisBalancePoint struct {
+	Date           string  `json:"date"`
+	Income         float64 `json:"income"`
+	Expense        float64 `json:"expense"`
+	Net            float64 `json:"net"`
+	Balance        float64 `json:"balance"`
+	BalanceDisplay string  `json:"balance_display"`
+}
+
+type AnalysisComparison struct {
+	PreviousFrom          string                   `json:"previous_from"`
+	PreviousTo            string                   `json:"previous_to"`
+	PreviousLabel         string                   `json:"previous_label"`
+	Expense               AnalysisChange           `json:"expense"`
+	Income                AnalysisChange           `json:"income"`
+	Categories            []AnalysisCategoryChange `json:"categories"`
+	NewCategories         []string                 `json:"new_categories"`
+	DisappearedCategories []string                 `json:"disappeared_categories"`
+}
+
+type AnalysisChange struct {
+	Current         float64 `json:"current"`
+	Previous        float64 `json:"previous"`
+	PreviousDisplay string  `json:"previous_display"`
+	Change          float64 `json:"change"`
+	ChangeDisplay   string  `json:"change_display"`
+	ChangePercent   float64 `json:"change_percent"` // 0 when the previous amount is 0
+}
+
+type AnalysisCategoryChange struct {
+	Type            string  `json:"type"` // "expense" or "income"
+	Name            string  `json:"name,omitempty"`
+	CategoryName    string  `json:"category_name,omitempty"`
+	SubCategoryName string  `json:"sub_category_name,omitempty"`
+	Current         float64 `json:"current"`
+	Previous        float64 `json:"previous"`
+	Change          float64 `json:"change"`
+	ChangeDisplay   string  `json:"change_display"`
+	ChangePercent   float64 `json:"change_percent"`
+	Status          string  `json:"status"` // "new", "disappeared", "increased", "decreased" or "unchanged"
+}
+
+type TagAnalysisResponse struct {
+	From string            `json:"from"`
+	To   string            `json:"to"`
+	Tags []TagAnalysisItem `json:"tags"`
+}
+
+type TagAnalysisItem struct {
+	Tag                 string                `json:"tag"`
+	TotalExpense        float64               `json:"total_expense"`
+	TotalExpenseDisplay string                `json:"total_expense_display"`
+	TotalIncome         float64               `json:"total_income"`
+	TotalIncomeDisplay  string                `json:"total_income_display"`
+	Count               int                   `json:"count"`
+	Categories          []TagAnalysisCategory `json:"categories"`
+}
+
+type TagAnalysisCategory struct {
+	Type          string  `json:"type"` // "expense" or "income"
+	Category      string  `json:"category"`
+	Amount        float64 `json:"amount"`
+	AmountDisplay string  `json:"amount_display"`
+	Count         int     `json:"count"`
+}
+
+type YearSpreadsheetItem struct {
+	Year          int    `json:"year"`
+	SpreadsheetID string `json:"spreadsheet_id"`
+	Current       bool   `json:"current"` // the spreadsheet of the request
+}
+
+type TrendAnalysisResponse struct {
+	From                string                `json:"from"`
+	To                  string                `json:"to"`
+	Interval            string                `json:"interval"` // "day", "week" or "month"
+	TotalExpense        float64               `json:"total_expense"`
+	TotalExpenseDisplay string                `json:"total_expense_display"`
+	TotalIncome         float64               `json:"total_income"`
+	TotalIncomeDisplay  string                `json:"total_income_display"`
+	Points              []TrendPoint          `json:"points"`
+	Categories          []TrendCategorySeries `json:"categories,omitempty"` // only with by_category=true
+}
+
+type TrendPoint struct {
+	Start          string  `json:"start"`
+	End            string  `json:"end"`
+	Label          string  `json:"label"`
+	Expense        float64 `json:"expense"`
+	ExpenseDisplay string  `json:"expense_display"`
+	Income         float64 `json:"income"`
+	IncomeDisplay  string  `json:"income_display"`
+}
+
+type TrendCategorySeries struct {
+	Type     string    `json:"type"` // "expense" or "income"
+	Category string    `json:"category"`
+	Total    float64   `json:"total"`
+	Values   []float64 `json:"values"` // one value per point
+}
+
+type HeatmapResponse struct {
+	From               string            `json:"from"`
+	To                 string            `json:"to"`
+	Days               []string          `json:"days"`  // row labels, Monday first
+	Cells              [][]HeatmapCell   `json:"cells"` // [day][hour], 7 x 24
+	TotalCount         int               `json:"total_count"`
+	TotalAmount        float64           `json:"total_amount"`
+	TotalAmountDisplay string            `json:"total_amount_display"`
+	Peak               *HeatmapPeak      `json:"peak"` // null without expenses
+	Categories         []HeatmapCategory `json:"categories"`
+}
+
+type HeatmapCell struct {
+	Count  int     `json:"count"`
+	Amount float64 `json:"amount"`
+}
+
+type HeatmapPeak struct {
+	Day           string  `json:"day"`
+	Hour          int     `json:"hour"`
+	Count         int     `json:"count"`
+	Amount        float64 `json:"amount"`
+	AmountDisplay string  `json:"amount_display"`
+}
+
+type HeatmapCategory struct {
+	Category      string          `json:"category"`
+	Count         int             `json:"count"`
+	Amount        float64         `json:"amount"`
+	AmountDisplay string          `json:"amount_display"`
+	Cells         [][]HeatmapCell `json:"cells,omitempty"` // only with by_category=true
+	Peak          *HeatmapPeak    `json:"peak,omitempty"`
+}
+
+type MemberAnalysisResponse struct {
+	From    string               `json:"from"`
+	To      string               `json:"to"`
+	Members []MemberAnalysisItem `json:"members"`
+}
+
+type MemberAnalysisItem struct {
+	Member               string                         `json:"member"` // creator email, "unknown" when missing
+	TotalExpense         float64                        `json:"total_expense"`
+	TotalExpenseDisplay  string                         `json:"total_expense_display"`
+	TotalIncome          float64                        `json:"total_income"`
+	TotalIncomeDisplay   string                         `json:"total_income_display"`
+	ExpenseShare         float64                        `json:"expense_share"` // percent of all expenses in the range
+	TransactionCount     int                            `json:"transaction_count"`
+	Categories           []MemberAnalysisCategory       `json:"categories"`
+	PriorityDistribution []AnalysisPriorityDistribution `json:"priority_distribution"`
+}
+
+type MemberAnalysisCategory struct {
+	Type          string  `json:"type"` // "expense" or "income"
+	Category      string  `json:"category"`
+	Amount        float64 `json:"amount"`
+	AmountDisplay string  `json:"amount_display"`
+	Count         int     `json:"count"`
+}
+
+type YearlyAnalysisResponse struct {
+	Year                int              `json:"year"`
+	TotalExpense        float64          `json:"total_expense"`
+	TotalExpenseDisplay string           `json:"total_expense_display"`
+	TotalIncome         float64          `json:"total_income"`
+	TotalIncomeDisplay  string           `json:"total_income_display"`
+	Net                 float64          `json:"net"`
+	NetDisplay          string           `json:"net_display"`
+	SavingsRate         float64          `json:"savings_rate"`
+	MonthsWithinBudget  int              `json:"months_within_budget"` // completed months not over budget
+	Months              []YearlyMonth    `json:"months"`
+	TopCategories       []YearlyCategory `json:"top_categories"`
+	TopMerchants        []YearlyMerchant `json:"top_merchants"`
+	LargestExpenses     []YearlyExpense  `json:"largest_expenses"`
+}
+
+type YearlyMonth struct {
+	Month               int     `json:"month"`
+	Name                string  `json:"name"`
+	HasTab              bool    `json:"has_tab"`
+	TotalExpense        float64 `json:"total_expense"`
+	TotalExpenseDisplay string  `json:"total_expense_display"`
+	TotalIncome         float64 `json:"total_income"`
+	TotalIncomeDisplay  string  `json:"total_income_display"`
+	Net                 float64 `json:"net"`
+	NetDisplay          string  `json:"net_display"`
+	SavingsRate         float64 `json:"savings_rate"`
+	Budget              float64 `json:"budget"`
+	BudgetDisplay       string  `json:"budget_display"`
+	PercentUsed         float64 `json:"percent_used"`
+	BudgetStatus        string  `json:"budget_status"` // on_track, warning, over or no_budget
+}
+
+type YearlyCategory struct {
+	Category      string  `json:"category"`
+	Amount        float64 `json:"amount"`
+	AmountDisplay string  `json:"amount_display"`
+	Percent       float64 `json:"percent"`
+	Count         int     `json:"count"`
+}
+
+type YearlyMerchant struct {
+	Description   string  `json:"description"`
+	Amount        float64 `json:"amount"`
+	AmountDisplay string  `json:"amount_display"`
+	Count         int     `json:"count"`
+}
+
+type YearlyExpense struct {
+	ID            string  `json:"id"`
+	SheetName     string  `json:"sheet_name"`
+	Description   string  `json:"description"`
+	Category      string  `json:"category"`
+	Amount        float64 `json:"amount"`
+	AmountDisplay string  `json:"amount_display"`
+	Date          string  `json:"date"`
+}
+
+type PriorityAnalysisResponse struct {
+	From                 string           `json:"from"`
+	To                   string           `json:"to"`
+	Interval             string           `json:"interval"` // "day", "week" or "month"
+	TotalIncome          float64          `json:"total_income"`
+	Unprioritized        float64          `json:"unprioritized"` // expenses without a known priority
+	UnprioritizedDisplay string           `json:"unprioritized_display"`
+	Levels               []PriorityLevel  `json:"levels"`
+	Periods              []PriorityPeriod `json:"periods"`
+	WhatIf               PriorityWhatIf   `json:"what_if"`
+}
+
+type PriorityLevel struct {
+	Level         string                `json:"level"` // high, medium or low
+	Label         string                `json:"label"`
+	Amount        float64               `json:"amount"`
+	AmountDisplay string                `json:"amount_display"`
+	Share         float64               `json:"share"` // percent of prioritized spending
+	SubCategories []PrioritySubCategory `json:"sub_categories"`
+}
+
+type PrioritySubCategory struct {
+	SubCategoryName string  `json:"sub_category_name"`
+	Amount          float64 `json:"amount"`
+	AmountDisplay   string  `json:"amount_display"`
+	Percent         float64 `json:"percent"` // percent of the priority level
+	Count           int     `json:"count"`
+}
+
+type PriorityPeriod struct {
+	Start   string             `json:"start"`
+	Label   string             `json:"label"`
+	Total   float64            `json:"total"`
+	Amounts map[string]float64 `json:"amounts"` // per level
+	Shares  map[string]float64 `json:"shares"`  // percent per level
+}
+
+type PriorityWhatIf struct {
+	CutPercent            float64 `json:"cut_percent"`
+	LowPriority           float64 `json:"low_priority"`
+	Savings               float64 `json:"savings"`
+	SavingsDisplay        string  `json:"savings_display"`
+	MonthlySavings        float64 `json:"monthly_savings"`
+	MonthlySavingsDisplay string  `json:"monthly_savings_display"`
+	CurrentSavingsRate    float64 `json:"current_savings_rate"`
+	NewSavingsRate        float64 `json:"new_savings_rate"`
+}
@@ -0,0 +1,9 @@
+package response
+
+type AttachmentItem struct {
+	ID            string `json:"id"`
+	TransactionID string `json:"transaction_id"`
+	ContentType   string `json:"content_type"`
+	Size          int64  `json:"size,omitempty"`
+	DownloadURL   string `json:"download_url"`
+}
@@ -0,0 +1,118 @@
+package response
+
+type BudgetStatusResponse struct {
+	SheetName             string             `json:"sheet_name"`
+	MonthProgress         float64            `json:"month_progress"` // percent of the month elapsed
+	MonthlyBudget         float64            `json:"monthly_budget"`
+	TotalBudget           float64            `json:"total_budget"`
+	TotalBudgetDisplay    string             `json:"total_budget_display"`
+	TotalSpent            float64            `json:"total_spent"`
+	TotalSpentDisplay     string             `json:"total_spent_display"`
+	TotalRemaining        float64            `json:"total_remaining"`
+	TotalRemainingDisplay string             `json:"total_remaining_display"`
+	PercentUsed           float64            `json:"percent_used"`
+	Status                string             `json:"status"`
+	Categories            []BudgetStatusItem `json:"categories"`
+}
+
+type BudgetStatusItem struct {
+	CategoryName           string  `json:"category_name"`
+	SubCategoryName        string  `json:"sub_category_name"`
+	Budget                 float64 `json:"budget"`
+	BudgetDisplay          string  `json:"budget_display"`
+	RolloverMode           string  `json:"rollover_mode"` // none, surplus or surplus_and_deficit
+	CarryOver              float64 `json:"carry_over"`
+	EffectiveBudget        float64 `json:"effective_budget"`
+	EffectiveBudgetDisplay string  `json:"effective_budget_display"`
+	Spent                  float64 `json:"spent"`
+	SpentDisplay           string  `json:"spent_display"`
+	Remaining              float64 `json:"remaining"`
+	RemainingDisplay       string  `json:"remaining_display"`
+	PercentUsed            float64 `json:"percent_used"`
+	Status                 string  `json:"status"` // on_track, warning or over
+}
+
+type DailyBudgetResponse struct {
+	Date                  string           `json:"date"`
+	DailyBudget           float64          `json:"daily_budget"`
+	CarryOver             float64          `json:"carry_over"`
+	Allowance             float64          `json:"allowance"`
+	AllowanceDisplay      string           `json:"allowance_display"`
+	SpentToday            float64          `json:"spent_today"`
+	SpentTodayDisplay     string           `json:"spent_today_display"`
+	RemainingToday        float64          `json:"remaining_today"`
+	RemainingTodayDisplay string           `json:"remaining_today_display"`
+	Status                string           `json:"status"` // on_track or over
+	Days                  []DailyBudgetDay `json:"days"`
+}
+
+type DailyBudgetDay struct {
+	Date      string  `json:"date"`
+	Allowance float64 `json:"allowance"`
+	CarryOver float64 `json:"carry_over"`
+	Spent     float64 `json:"spent"`
+	Remaining float64 `json:"remaining"`
+}
+
+type BudgetRolloverItem struct {
+	CategoryName    string `json:"category_name"`
+	SubCategoryName string `json:"sub_category_name"`
+	Mode            string `json:"mode"`
+}
+
+type BudgetTemplateItem struct {
+	Name          string                   `json:"name"`
+	DailyBudget   float64                  `json:"daily_budget"`
+	MonthlyBudget float64                  `json:"monthly_budget"`
+	TotalBudget   float64                  `json:"total_budget"`
+	Categories    []BudgetTemplateCategory `json:"categories"`
+}
+
+type BudgetTemplateCategory struct {
+	CategoryName    string  `json:"category_name"`
+	SubCategoryName string  `json:"sub_category_name"`
+	Budget          float64 `json:"budget"`
+}
+
+type BudgetForecastResponse struct {
+	SheetName                 string                    `json:"sheet_name"`
+	AsOf                      string                    `json:"as_of"`
+	DaysElapsed               int                       `json:"days_elapsed"`
+	DaysInMonth               int                       `json:"days_in_month"`
+	MonthlyBudget             float64                   `json:"monthly_budget"` // F5
+	TotalSpent                float64                   `json:"total_spent"`
+	TotalSpentDisplay         string                    `json:"total_spent_display"`
+	ProjectedTotal            float64                   `json:"projected_total"`
+	ProjectedTotalDisplay     string                    `json:"projected_total_display"`
+	ProjectedRemaining        float64                   `json:"projected_remaining"` // monthly budget minus projection
+	ProjectedRemainingDisplay string                    `json:"projected_remaining_display"`
+	Status                    string                    `json:"status"` // on_track or over
+	Categories                []BudgetForecastItem      `json:"categories"`
+	RecurringItems            []BudgetForecastRecurring `json:"recurring_items"`
+}
+
+type BudgetForecastItem struct {
+	CategoryName              string  `json:"category_name"`
+	SubCategoryName           string  `json:"sub_category_name"`
+	Budget                    float64 `json:"budget"` // including rollover
+	BudgetDisplay             string  `json:"budget_display"`
+	Spent                     float64 `json:"spent"`
+	SpentDisplay              string  `json:"spent_display"`
+	RecurringPending          float64 `json:"recurring_pending"` // recurring items not recorded yet
+	LastYearSameMonth         float64 `json:"last_year_same_month"`
+	Projected                 float64 `json:"projected"`
+	ProjectedDisplay          string  `json:"projected_display"`
+	ProjectedRemaining        float64 `json:"projected_remaining"`
+	ProjectedRemainingDisplay string  `json:"projected_remaining_display"`
+	Method                    string  `json:"method"` // pace, pace_and_last_year, last_year, budget or actual
+	Status                    string  `json:"status"` // on_track or over
+}
+
+type BudgetForecastRecurring struct {
+	Description           string  `json:"description"`
+	SubCategoryName       string  `json:"sub_category_name"`
+	ExpectedAmount        float64 `json:"expected_amount"`
+	ExpectedAmountDisplay string  `json:"expected_amount_display"`
+	ExpectedDay           int     `json:"expected_day"`
+	Paid                  bool    `json:"paid"`
+}
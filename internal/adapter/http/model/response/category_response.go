@@ -4,6 +4,9 @@ type CategoryItem struct {
 	CategoryName    string  `json:"category_name"`
 	SubCategoryName string  `json:"sub_category_name"`
 	Budget          float64 `json:"budget"`
+	RolloverMode    string  `json:"rollover_mode"`
+	CarryOver       float64 `json:"carry_over"`
+	EffectiveBudget float64 `json:"effective_budget"`
 }
 
 type CategoryResponse struct {
@@ -11,3 +14,19 @@ type CategoryResponse struct {
 	MonthlyBudget float64        `json:"monthly_budget"`
 	Categories    []CategoryItem `json:"categories"`
 }
+
+type UnknownCategoryResponse struct {
+	SheetName    string                `json:"sheet_name"`
+	Transactions []UnknownCategoryItem `json:"transactions"`
+}
+
+type UnknownCategoryItem struct {
+	ID              string  `json:"id"`
+	Type            string  `json:"type"`
+	TransactionName string  `json:"transaction_name"`
+	Category        string  `json:"category"`
+	Suggestion      string  `json:"suggestion,omitempty"`
+	Amount          float64 `json:"amount"`
+	AmountDisplay   string  `json:"amount_display"`
+	Time            string  `json:"time"`
+}
@@ -0,0 +1,53 @@
+package response
+
+type DebtResponse struct {
+	TotalDebt              float64                   `json:"total_debt"`
+	TotalDebtDisplay       string                    `json:"total_debt_display"`
+	TotalReceivable        float64                   `json:"total_receivable"`
+	TotalReceivableDisplay string                    `json:"total_receivable_display"`
+	UpcomingInstallments   []DebtUpcomingInstallment `json:"upcoming_installments"`
+	Debts                  []DebtItem                `json:"debts"`
+}
+
+type DebtItem struct {
+	ID                 string            `json:"id"`
+	Type               string            `json:"type"` // debt or receivable
+	Counterparty       string            `json:"counterparty"`
+	Principal          float64           `json:"principal"`
+	PrincipalDisplay   string            `json:"principal_display"`
+	Paid               float64           `json:"paid"`
+	PaidDisplay        string            `json:"paid_display"`
+	Outstanding        float64           `json:"outstanding"`
+	OutstandingDisplay string            `json:"outstanding_display"`
+	Status             string            `json:"status"` // active or settled
+	Notes              string            `json:"notes"`
+	InstallmentsPaid   int               `json:"installments_paid"`
+	NextInstallment    *DebtInstallment  `json:"next_installment,omitempty"`
+	Installments       []DebtInstallment `json:"installments"`
+	Payments           []DebtPaymentItem `json:"payments"`
+}
+
+type DebtInstallment struct {
+	Number        int     `json:"number"`
+	DueDate       string  `json:"due_date"`
+	Amount        float64 `json:"amount"`
+	AmountDisplay string  `json:"amount_display"`
+	Paid          float64 `json:"paid"`
+	Status        string  `json:"status"` // paid, pending or overdue
+}
+
+type DebtPaymentItem struct {
+	ID            string  `json:"id"`
+	TransactionID string  `json:"transaction_id"`
+	SheetName     string  `json:"sheet_name"`
+	Amount        float64 `json:"amount"`
+	AmountDisplay string  `json:"amount_display"`
+	PaidAt        string  `json:"paid_at"`
+}
+
+type DebtUpcomingInstallment struct {
+	DebtID       string          `json:"debt_id"`
+	Type         string          `json:"type"`
+	Counterparty string          `json:"counterparty"`
+	Installment  DebtInstallment `json:"installment"`
+}
@@ -0,0 +1,13 @@
+package response
+
+type ExchangeRateResponse struct {
+	BaseCurrency string             `json:"base_currency"`
+	Rates        []ExchangeRateItem `json:"rates"`
+}
+
+type ExchangeRateItem struct {
+	Currency      string  `json:"currency"`
+	Rate          float64 `json:"rate"`
+	EffectiveDate string  `json:"effective_date"`
+	Source        string  `json:"source"`
+}
@@ -0,0 +1,24 @@
+package response
+
+type GoalResponse struct {
+	Goals []GoalItem `json:"goals"`
+}
+
+type GoalItem struct {
+	ID                     string  `json:"id"`
+	Name                   string  `json:"name"`
+	TargetAmount           float64 `json:"target_amount"`
+	TargetAmountDisplay    string  `json:"target_amount_display"`
+	Deadline               string  `json:"deadline"`
+	SavedAmount            float64 `json:"saved_amount"`
+	SavedAmountDisplay     string  `json:"saved_amount_display"`
+	RemainingAmount        float64 `json:"remaining_amount"`
+	RemainingAmountDisplay string  `json:"remaining_amount_display"`
+	ProgressPercent        float64 `json:"progress_percent"`
+	RequiredMonthly        float64 `json:"required_monthly"`
+	RequiredMonthlyDisplay string  `json:"required_monthly_display"`
+	MonthlyPace            float64 `json:"monthly_pace"`
+	MonthlyPaceDisplay     string  `json:"monthly_pace_display"`
+	ProjectedCompletion    string  `json:"projected_completion,omitempty"`
+	Status                 string  `json:"status"` // completed, on_track, behind, no_progress
+}
@@ -0,0 +1,23 @@
+package response
+
+type AnomalyResponse struct {
+	From      string        `json:"from"`
+	To        string        `json:"to"`
+	Anomalies []AnomalyItem `json:"anomalies"`
+}
+
+type AnomalyItem struct {
+	Type            string  `json:"type"`     // "amount", "description" or "daily_spike"
+	Severity        string  `json:"severity"` // "low", "medium" or "high"
+	Date            string  `json:"date"`
+	TransactionID   string  `json:"transaction_id,omitempty"` // empty for daily spikes
+	SheetName       string  `json:"sheet_name,omitempty"`
+	Description     string  `json:"description,omitempty"`
+	Category        string  `json:"category,omitempty"`
+	Amount          float64 `json:"amount"`
+	AmountDisplay   string  `json:"amount_display"`
+	Expected        float64 `json:"expected"` // typical amount the finding is measured against
+	ExpectedDisplay string  `json:"expected_display"`
+	Score           float64 `json:"score"`
+	Explanation     string  `json:"explanation"`
+}
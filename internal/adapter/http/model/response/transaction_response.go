@@ -13,12 +13,22 @@ type TransactionGroupResponse struct {
 }
 
 type TransactionItemResponse struct {
-	ID              string  `json:"id"`
-	TransactionName string  `json:"transaction_name"`
-	Category        string  `json:"category"`
-	Time            string  `json:"time"`
-	Amount          float64 `json:"amount"`
-	AmountDisplay   string  `json:"amount_display"`
-	Type            string  `json:"type"`            // "expense" or "income"
-	Label           string  `json:"label,omitempty"` // "PEMASUKAN" for income
+	ID                    string   `json:"id"`
+	TransactionName       string   `json:"transaction_name"`
+	Category              string   `json:"category"`
+	Time                  string   `json:"time"`
+	Amount                float64  `json:"amount"`
+	AmountDisplay         string   `json:"amount_display"`
+	Type                  string   `json:"type"`            // "expense", "income" or "transfer"
+	Label                 string   `json:"label,omitempty"` // "PEMASUKAN" for income, "TRANSFER" for transfers
+	Currency              string   `json:"currency"`        // original currency; amount is always in the base currency
+	OriginalAmount        float64  `json:"original_amount,omitempty"`
+	OriginalAmountDisplay string   `json:"original_amount_display,omitempty"`
+	ExchangeRate          float64  `json:"exchange_rate,omitempty"`
+	SplitGroup            string   `json:"split_group,omitempty"`
+	AccountID             string   `json:"account_id"`              // source account for transfers
+	ToAccountID           string   `json:"to_account_id,omitempty"` // destination account for transfers
+	Tags                  []string `json:"tags,omitempty"`
+	Attachments           []string `json:"attachments,omitempty"`
+	CreatedBy             string   `json:"created_by,omitempty"` // email of the member who entered it
 }
@@ -9,7 +9,7 @@ import (
 )
 
 // SetupRoutes registers all application routes
-func SetupRoutes(e *echo.Echo, authCtrl *controller.AuthController, transactionCtrl *controller.TransactionController, categoryCtrl *controller.CategoryController, authUsecase *usecase.AuthUsecase) {
+func SetupRoutes(e *echo.Echo, authCtrl *controller.AuthController, transactionCtrl *controller.TransactionController, categoryCtrl *controller.CategoryController, accountCtrl *controller.AccountController, exchangeRateCtrl *controller.ExchangeRateController, goalCtrl *controller.GoalController, debtCtrl *controller.DebtController, budgetCtrl *controller.BudgetController, attachmentCtrl *controller.AttachmentController, authUsecase *usecase.AuthUsecase) {
 	// Health check
 	e.GET("/health", func(c echo.Context) error {
 		return c.JSON(200, map[string]string{
@@ -33,17 +33,75 @@ func SetupRoutes(e *echo.Echo, authCtrl *controller.AuthController, transactionC
 	// Transaction routes
 	api.POST("/transaction/income", transactionCtrl.AddIncomeTransaction)
 	api.POST("/transaction/expense", transactionCtrl.AddExpenseTransaction)
+	api.POST("/transaction/transfer", transactionCtrl.AddTransferTransaction)
 	api.GET("/transaction", transactionCtrl.ListTransaction)
 	api.PUT("/transaction", transactionCtrl.UpdateTransaction)
+	api.DELETE("/transaction/:id", transactionCtrl.DeleteTransaction)
+
+	// Attachment routes
+	api.POST("/transaction/:id/attachments", attachmentCtrl.UploadAttachment)
+	api.GET("/transaction/:id/attachments", attachmentCtrl.ListAttachment)
+	api.GET("/transaction/:id/attachments/:attachment_id", attachmentCtrl.DownloadAttachment)
+	api.DELETE("/transaction/:id/attachments/:attachment_id", attachmentCtrl.DeleteAttachment)
 
 	// Category routes
 	api.GET("/category", categoryCtrl.ListCategory)
 	api.POST("/category", categoryCtrl.SaveCategory)
 	api.PUT("/category", categoryCtrl.SaveCategory)
+	api.POST("/category/item", categoryCtrl.AddCategoryItem)
+	api.PUT("/category/item", categoryCtrl.UpdateCategoryItem)
+	api.DELETE("/category/item", categoryCtrl.DeleteCategoryItem)
+	api.GET("/category/unknown", categoryCtrl.ListUnknownCategoryTransaction)
 
 	api.GET("/category/income", categoryCtrl.ListIncomeCategory)
+	api.POST("/category/income", categoryCtrl.AddIncomeCategory)
+	api.PUT("/category/income", categoryCtrl.RenameIncomeCategory)
+	api.DELETE("/category/income", categoryCtrl.DeleteIncomeCategory)
+
+	// Budget routes
+	api.GET("/budget/status", budgetCtrl.GetBudgetStatus)
+	api.GET("/budget/daily", budgetCtrl.GetDailyBudget)
+	api.GET("/budget/forecast", budgetCtrl.GetBudgetForecast)
+	api.GET("/budget/rollover", budgetCtrl.ListBudgetRollover)
+	api.PUT("/budget/rollover", budgetCtrl.SaveBudgetRollover)
+	api.GET("/budget/templates", budgetCtrl.ListBudgetTemplate)
+	api.POST("/budget/templates", budgetCtrl.SaveBudgetTemplate)
+	api.POST("/budget/apply", budgetCtrl.ApplyBudget)
+
+	// Account routes
+	api.GET("/accounts", accountCtrl.ListAccount)
+	api.POST("/accounts", accountCtrl.CreateAccount)
+	api.GET("/accounts/:id/balances", accountCtrl.ListAccountBalance)
+
+	// Exchange rate routes
+	api.GET("/exchange-rates", exchangeRateCtrl.ListExchangeRate)
+	api.POST("/exchange-rates", exchangeRateCtrl.SaveExchangeRate)
+	api.POST("/exchange-rates/import", exchangeRateCtrl.ImportExchangeRate)
+
+	// Goal routes
+	api.GET("/goals", goalCtrl.ListGoal)
+	api.POST("/goals", goalCtrl.CreateGoal)
+	api.POST("/goals/:id/contributions", goalCtrl.AddContribution)
+
+	// Debt routes
+	api.GET("/debts", debtCtrl.ListDebt)
+	api.POST("/debts", debtCtrl.CreateDebt)
+	api.POST("/debts/:id/payments", debtCtrl.AddPayment)
+
+	// Year spreadsheet routes
+	api.GET("/spreadsheets", transactionCtrl.ListYearSpreadsheet)
+	api.PUT("/spreadsheets", transactionCtrl.SaveYearSpreadsheet)
 
 	// Analysis routes
 	api.GET("/analysis", transactionCtrl.GetAnalysis)
+	api.GET("/analysis/tags", transactionCtrl.GetTagAnalysis)
+	api.GET("/analysis/trend", transactionCtrl.GetTrendAnalysis)
+	api.GET("/analysis/heatmap", transactionCtrl.GetHeatmapAnalysis)
+	api.GET("/analysis/members", transactionCtrl.GetMemberAnalysis)
+	api.GET("/analysis/yearly", transactionCtrl.GetYearlyAnalysis)
+	api.GET("/analysis/priority", transactionCtrl.GetPriorityAnalysis)
+
+	// Insight routes
+	api.GET("/insights/anomalies", transactionCtrl.GetAnomalies)
 
 }
@@ -1,11 +1,16 @@
 package repository
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
+	"regexp"
 	"strconv"
+	"strings"
 
 	"byeboros-backend/internal/infrastructure/gsheet"
 
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/sheets/v4"
 )
 
@@ -41,6 +46,57 @@ func (r *SheetRepository) GetRangeValues(spreadsheetID, rangeStr string) ([][]in
 	return resp.Values, nil
 }
 
+// GetRangeValuesUnformatted returns raw values from a range string: numbers as float64 and
+// dates as serial numbers, independent of the spreadsheet locale and cell formats
+func (r *SheetRepository) GetRangeValuesUnformatted(spreadsheetID, rangeStr string) ([][]interface{}, error) {
+	resp, err := r.client.Service.Spreadsheets.Values.Get(
+		spreadsheetID, rangeStr,
+	).ValueRenderOption("UNFORMATTED_VALUE").DateTimeRenderOption("SERIAL_NUMBER").Do()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get range values: %w", err)
+	}
+	return resp.Values, nil
+}
+
+// GetOptionalRangeValuesUnformatted is GetRangeValuesUnformatted for sheets that are only
+// created on first write: a range of a sheet that does not exist yet reads as empty
+func (r *SheetRepository) GetOptionalRangeValuesUnformatted(spreadsheetID, rangeStr string) ([][]interface{}, error) {
+	values, err := r.GetRangeValuesUnformatted(spreadsheetID, rangeStr)
+	if err == nil {
+		return values, nil
+	}
+
+	// A range naming a missing sheet fails with a plain 400, so check the sheet list to tell
+	// it apart from other bad requests
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != http.StatusBadRequest {
+		return nil, err
+	}
+	names, listErr := r.ListSheetNames(spreadsheetID)
+	if listErr != nil {
+		return nil, err
+	}
+	sheetName := rangeSheetName(rangeStr)
+	for _, name := range names {
+		if name == sheetName {
+			return nil, err
+		}
+	}
+	return nil, nil
+}
+
+// rangeSheetName returns the sheet part of an A1 range such as "'Budget Rollover'!A2:C"
+func rangeSheetName(rangeStr string) string {
+	name := rangeStr
+	if i := strings.LastIndex(rangeStr, "!"); i >= 0 {
+		name = rangeStr[:i]
+	}
+	if len(name) >= 2 && strings.HasPrefix(name, "'") && strings.HasSuffix(name, "'") {
+		name = strings.ReplaceAll(name[1:len(name)-1], "''", "'")
+	}
+	return name
+}
+
 // BatchGetValues returns values from multiple range strings
 func (r *SheetRepository) BatchGetValues(spreadsheetID string, ranges []string) ([]*sheets.ValueRange, error) {
 	resp, err := r.client.Service.Spreadsheets.Values.BatchGet(spreadsheetID).Ranges(ranges...).Do()
@@ -271,6 +327,20 @@ func (r *SheetRepository) GetSheetID(spreadsheetID, sheetName string) (int64, er
 	return 0, fmt.Errorf("sheet '%s' not found", sheetName)
 }
 
+// ListSheetNames returns the titles of all sheets in a spreadsheet, in tab order
+func (r *SheetRepository) ListSheetNames(spreadsheetID string) ([]string, error) {
+	spreadsheet, err := r.client.Service.Spreadsheets.Get(spreadsheetID).Do()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get spreadsheet: %w", err)
+	}
+
+	names := make([]string, 0, len(spreadsheet.Sheets))
+	for _, s := range spreadsheet.Sheets {
+		names = append(names, s.Properties.Title)
+	}
+	return names, nil
+}
+
 // columnIndexToLetter converts a 0-based column index to a column letter (A, B, ..., Z, AA, AB, ...)
 func columnIndexToLetter(index int) string {
 	result := ""
@@ -329,6 +399,29 @@ func (r *SheetRepository) UpdateRange(spreadsheetID, rangeStr string, values [][
 	return nil
 }
 
+// BatchUpdateRanges updates several ranges in a single request, keyed by A1 range
+func (r *SheetRepository) BatchUpdateRanges(spreadsheetID string, data map[string][][]interface{}) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	req := &sheets.BatchUpdateValuesRequest{
+		ValueInputOption: "USER_ENTERED",
+	}
+	for rangeStr, values := range data {
+		req.Data = append(req.Data, &sheets.ValueRange{
+			Range:  rangeStr,
+			Values: values,
+		})
+	}
+
+	_, err := r.client.Service.Spreadsheets.Values.BatchUpdate(spreadsheetID, req).Do()
+	if err != nil {
+		return fmt.Errorf("failed to batch update ranges: %w", err)
+	}
+	return nil
+}
+
 // BatchAppendRows appends multiple rows at once
 func (r *SheetRepository) BatchAppendRows(spreadsheetID, sheetName string, rows [][]interface{}) error {
 	valueRange := &sheets.ValueRange{
@@ -344,6 +437,70 @@ func (r *SheetRepository) BatchAppendRows(spreadsheetID, sheetName string, rows
 	return nil
 }
 
+// AppendRowsAt appends multiple rows and returns the 1-based index of the first appended row
+func (r *SheetRepository) AppendRowsAt(spreadsheetID, rangeStr string, rows [][]interface{}) (int, error) {
+	valueRange := &sheets.ValueRange{
+		Values: rows,
+	}
+
+	resp, err := r.client.Service.Spreadsheets.Values.Append(
+		spreadsheetID, rangeStr, valueRange,
+	).ValueInputOption("USER_ENTERED").Do()
+	if err != nil {
+		return 0, fmt.Errorf("failed to append rows: %w", err)
+	}
+	if resp.Updates == nil {
+		return 0, fmt.Errorf("append response did not include the updated range")
+	}
+	return rangeStartRow(resp.Updates.UpdatedRange)
+}
+
+// EnsureSheet creates the sheet with the given header row if it does not exist yet
+func (r *SheetRepository) EnsureSheet(spreadsheetID, sheetName string, headers []interface{}) error {
+	spreadsheet, err := r.client.Service.Spreadsheets.Get(spreadsheetID).Do()
+	if err != nil {
+		return fmt.Errorf("failed to get spreadsheet: %w", err)
+	}
+
+	for _, s := range spreadsheet.Sheets {
+		if s.Properties.Title == sheetName {
+			return nil
+		}
+	}
+
+	req := &sheets.BatchUpdateSpreadsheetRequest{
+		Requests: []*sheets.Request{
+			{
+				AddSheet: &sheets.AddSheetRequest{
+					Properties: &sheets.SheetProperties{Title: sheetName},
+				},
+			},
+		},
+	}
+	if _, err := r.client.Service.Spreadsheets.BatchUpdate(spreadsheetID, req).Do(); err != nil {
+		return fmt.Errorf("failed to create sheet '%s': %w", sheetName, err)
+	}
+
+	if len(headers) > 0 {
+		if err := r.UpdateRow(spreadsheetID, sheetName, 1, headers); err != nil {
+			return fmt.Errorf("failed to write headers for sheet '%s': %w", sheetName, err)
+		}
+	}
+	return nil
+}
+
+// rangeStartRowPattern matches the first row number of an A1 range such as "Januari!A12:G14"
+var rangeStartRowPattern = regexp.MustCompile(`![A-Z]+(\d+)`)
+
+// rangeStartRow extracts the 1-based first row index from an A1 range string
+func rangeStartRow(rangeStr string) (int, error) {
+	match := rangeStartRowPattern.FindStringSubmatch(rangeStr)
+	if len(match) < 2 {
+		return 0, fmt.Errorf("cannot parse row from range %s", rangeStr)
+	}
+	return strconv.Atoi(match[1])
+}
+
 // RowToMap converts a row ([]interface{}) to a map using the provided headers
 func RowToMap(headers []interface{}, row []interface{}) map[string]interface{} {
 	record := make(map[string]interface{})
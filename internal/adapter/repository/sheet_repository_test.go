@@ -0,0 +1,21 @@
+package repository
+
+import "testing"
+
+func TestRangeSheetName(t *testing.T) {
+	tests := []struct {
+		rangeStr string
+		want     string
+	}{
+		{"Accounts!A2:E", "Accounts"},
+		{"Transaction Meta!A2:Z", "Transaction Meta"},
+		{"'Budget Rollover'!A2:C", "Budget Rollover"},
+		{"'Rudi''s Sheet'!A1", "Rudi's Sheet"},
+		{"Goals", "Goals"},
+	}
+	for _, tt := range tests {
+		if got := rangeSheetName(tt.rangeStr); got != tt.want {
+			t.Errorf("rangeSheetName(%q) = %q, want %q", tt.rangeStr, got, tt.want)
+		}
+	}
+}
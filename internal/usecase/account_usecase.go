@@ -0,0 +1,383 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"byeboros-backend/internal/adapter/http/model/request"
+	"byeboros-backend/internal/adapter/http/model/response"
+	"byeboros-backend/internal/adapter/repository"
+)
+
+const (
+	// accountSheet lists the household's accounts and wallets
+	accountSheet = "Accounts"
+
+	// DefaultAccountID is the wallet used for transactions that were not tagged with an account
+	DefaultAccountID = "default"
+)
+
+// ErrAccountNotFound is returned when an account ID or name does not match any account
+var ErrAccountNotFound = errors.New("account not found")
+
+var accountHeaders = []interface{}{"ID", "Name", "Type", "Opening Balance", "Created At"}
+
+// account is one row of the "Accounts" sheet
+type account struct {
+	Row            int // 1-based row in the accounts sheet, 0 for the built-in default wallet
+	ID             string
+	Name           string
+	Type           string
+	OpeningBalance float64
+}
+
+// AccountUsecase handles accounts, wallets and their balances
+type AccountUsecase struct {
+	sheetRepo    *repository.SheetRepository
+	baseCurrency string // currency stored amounts (columns D and K) are expressed in
+}
+
+// NewAccountUsecase creates a new AccountUsecase
+func NewAccountUsecase(sheetRepo *repository.SheetRepository, baseCurrency string) *AccountUsecase {
+	return &AccountUsecase{sheetRepo: sheetRepo, baseCurrency: baseCurrency}
+}
+
+// loadAccounts reads the "Accounts" sheet, which may not exist yet. The default wallet is
+// always present; a row with ID "default" overrides its name, type and opening balance.
+func loadAccounts(sheetRepo *repository.SheetRepository, spreadsheetID string) ([]*account, error) {
+	rows, err := sheetRepo.GetOptionalRangeValuesUnformatted(spreadsheetID, accountSheet+"!A2:E")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+
+	accounts := []*account{{ID: DefaultAccountID, Name: "Dompet", Type: "cash"}}
+	for i, row := range rows {
+		id := cellString(row, 0)
+		if id == "" {
+			continue
+		}
+		acc := &account{
+			Row:            i + 2,
+			ID:             id,
+			Name:           cellString(row, 1),
+			Type:           cellString(row, 2),
+			OpeningBalance: cellNumber(row, 3),
+		}
+		if id == DefaultAccountID {
+			accounts[0] = acc
+			continue
+		}
+		accounts = append(accounts, acc)
+	}
+	return accounts, nil
+}
+
+// findAccount looks up an account by ID (or case-insensitive name)
+func findAccount(accounts []*account, idOrName string) *account {
+	for _, acc := range accounts {
+		if acc.ID == idOrName || strings.EqualFold(acc.Name, idOrName) {
+			return acc
+		}
+	}
+	return nil
+}
+
+// resolveAccountID validates an account reference from a request and returns the ID to store.
+// Empty and default references are stored as empty so untagged rows stay untagged.
+func resolveAccountID(sheetRepo *repository.SheetRepository, spreadsheetID string, ref string) (string, error) {
+	if ref == "" || ref == DefaultAccountID {
+		return "", nil
+	}
+
+	accounts, err := loadAccounts(sheetRepo, spreadsheetID)
+	if err != nil {
+		return "", err
+	}
+
+	acc := findAccount(accounts, ref)
+	if acc == nil {
+		return "", fmt.Errorf("account '%s' not found", ref)
+	}
+	if acc.ID == DefaultAccountID {
+		return "", nil
+	}
+	return acc.ID, nil
+}
+
+// accountOrDefault maps an empty stored account to the default wallet ID
+func accountOrDefault(accountID string) string {
+	if accountID == "" {
+		return DefaultAccountID
+	}
+	return accountID
+}
+
+// CreateAccount adds a new account with its opening balance
+func (u *AccountUsecase) CreateAccount(spreadsheetID string, req request.CreateAccountRequest) (*response.AccountItem, error) {
+	accounts, err := loadAccounts(u.sheetRepo, spreadsheetID)
+	if err != nil {
+		return nil, err
+	}
+
+	if findAccount(accounts, req.Name) != nil {
+		return nil, fmt.Errorf("account '%s' already exists", req.Name)
+	}
+
+	acc := &account{
+		ID:             newID("acc"),
+		Name:           req.Name,
+		Type:           req.Type,
+		OpeningBalance: req.OpeningBalance,
+	}
+
+	values := []interface{}{
+		acc.ID,                                   // Column A
+		acc.Name,                                 // Column B
+		acc.Type,                                 // Column C
+		acc.OpeningBalance,                       // Column D
+		time.Now().Format("2006-01-02 15:04:05"), // Column E
+	}
+	if err := u.sheetRepo.EnsureSheet(spreadsheetID, accountSheet, accountHeaders); err != nil {
+		return nil, err
+	}
+	if err := u.sheetRepo.AppendRow(spreadsheetID, accountSheet+"!A:E", values); err != nil {
+		return nil, fmt.Errorf("failed to add account: %w", err)
+	}
+
+	item := buildAccountItem(acc, accountFlow{}, u.baseCurrency)
+	return &item, nil
+}
+
+// GetAccounts returns every account with its current balance computed from the
+// opening balance and all income/expense rows of the month tabs of every linked year
+func (u *AccountUsecase) GetAccounts(spreadsheetID string) (*response.AccountResponse, error) {
+	accounts, err := loadAccounts(u.sheetRepo, spreadsheetID)
+	if err != nil {
+		return nil, err
+	}
+
+	balances, err := u.accountBalances(spreadsheetID, accounts)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &response.AccountResponse{Accounts: make([]response.AccountItem, 0, len(accounts))}
+	for _, acc := range accounts {
+		flow := balances[acc.ID]
+		item := buildAccountItem(acc, *flow, u.baseCurrency)
+		res.Accounts = append(res.Accounts, item)
+		res.TotalBalance += item.Balance
+	}
+	res.TotalBalanceDisplay = formatBalance(res.TotalBalance, u.baseCurrency)
+
+	return res, nil
+}
+
+type accountFlow struct {
+	income      float64
+	expense     float64
+	transferIn  float64
+	transferOut float64
+}
+
+// accountMovement is one income, expense or transfer leg booked on an account
+type accountMovement struct {
+	AccountID string
+	Time      time.Time
+	Flow      accountFlow // exactly one field is set
+}
+
+// accountMovements reads the income, expense and transfer rows of every linked year, so
+// balances carry on across January. Rows without an account, or tagged with an account that
+// no longer exists, are booked on the default wallet. Movements are sorted by time.
+func (u *AccountUsecase) accountMovements(spreadsheetID string, accounts []*account) ([]accountMovement, error) {
+	spreadsheetIDs, err := linkedSpreadsheetIDs(u.sheetRepo, spreadsheetID)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []transactionRecord
+	var transfers []transfer
+	for _, id := range spreadsheetIDs {
+		yearRecords, err := loadAllRecords(u.sheetRepo, id)
+		if err != nil {
+			return nil, err
+		}
+		for _, rec := range yearRecords {
+			rec.SpreadsheetID = id
+			records = append(records, rec)
+		}
+
+		yearTransfers, err := loadTransfers(u.sheetRepo, id)
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, yearTransfers...)
+	}
+
+	metaIdx, err := loadRecordMeta(u.sheetRepo, spreadsheetID, records)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]bool, len(accounts))
+	for _, acc := range accounts {
+		known[acc.ID] = true
+	}
+	accountFor := func(accountID string) string {
+		if known[accountID] {
+			return accountID
+		}
+		return DefaultAccountID
+	}
+
+	movements := make([]accountMovement, 0, len(records)+2*len(transfers))
+	for _, rec := range records {
+		mv := accountMovement{AccountID: accountFor(metaIdx.get(rec).AccountID), Time: rec.Time}
+		if rec.Type == "income" {
+			mv.Flow.income = rec.Amount
+		} else {
+			mv.Flow.expense = rec.Amount
+		}
+		movements = append(movements, mv)
+	}
+	for _, trf := range transfers {
+		movements = append(movements,
+			accountMovement{AccountID: accountFor(trf.FromAccountID), Time: trf.Time, Flow: accountFlow{transferOut: trf.Amount}},
+			accountMovement{AccountID: accountFor(trf.ToAccountID), Time: trf.Time, Flow: accountFlow{transferIn: trf.Amount}},
+		)
+	}
+
+	sort.SliceStable(movements, func(i, j int) bool {
+		return movements[i].Time.Before(movements[j].Time)
+	})
+	return movements, nil
+}
+
+// accountBalances sums income, expense and transfers per account
+func (u *AccountUsecase) accountBalances(spreadsheetID string, accounts []*account) (map[string]*accountFlow, error) {
+	movements, err := u.accountMovements(spreadsheetID, accounts)
+	if err != nil {
+		return nil, err
+	}
+
+	flows := make(map[string]*accountFlow)
+	for _, acc := range accounts {
+		flows[acc.ID] = &accountFlow{}
+	}
+	for _, mv := range movements {
+		flows[mv.AccountID].add(mv.Flow)
+	}
+	return flows, nil
+}
+
+func (f *accountFlow) add(other accountFlow) {
+	f.income += other.income
+	f.expense += other.expense
+	f.transferIn += other.transferIn
+	f.transferOut += other.transferOut
+}
+
+func (f accountFlow) net() float64 {
+	return f.income - f.expense + f.transferIn - f.transferOut
+}
+
+// GetAccountBalances returns the running balance of one account at the end of every day
+// it had a movement, starting from its opening balance
+func (u *AccountUsecase) GetAccountBalances(spreadsheetID string, accountID string) (*response.AccountBalanceResponse, error) {
+	accounts, err := loadAccounts(u.sheetRepo, spreadsheetID)
+	if err != nil {
+		return nil, err
+	}
+
+	acc := findAccount(accounts, accountID)
+	if acc == nil {
+		return nil, fmt.Errorf("%w: %s", ErrAccountNotFound, accountID)
+	}
+
+	movements, err := u.accountMovements(spreadsheetID, accounts)
+	if err != nil {
+		return nil, err
+	}
+
+	days, total := runningBalances(acc.OpeningBalance, acc.ID, movements, u.baseCurrency)
+	return &response.AccountBalanceResponse{
+		Account: buildAccountItem(acc, total, u.baseCurrency),
+		Days:    days,
+	}, nil
+}
+
+// runningBalances folds an account's time-sorted movements into one entry per day holding
+// that day's flows and the balance at the end of the day. It also returns the total flow.
+func runningBalances(opening float64, accountID string, movements []accountMovement, baseCurrency string) ([]response.AccountBalanceDay, accountFlow) {
+	days := []response.AccountBalanceDay{}
+	var total, day accountFlow
+	var date string
+
+	flush := func() {
+		if date == "" {
+			return
+		}
+		balance := opening + total.net()
+		days = append(days, response.AccountBalanceDay{
+			Date:           date,
+			Income:         day.income,
+			Expense:        day.expense,
+			TransferIn:     day.transferIn,
+			TransferOut:    day.transferOut,
+			Balance:        balance,
+			BalanceDisplay: formatBalance(balance, baseCurrency),
+		})
+	}
+
+	for _, mv := range movements {
+		if mv.AccountID != accountID {
+			continue
+		}
+		if d := mv.Time.Format("2006-01-02"); d != date {
+			flush()
+			date, day = d, accountFlow{}
+		}
+		day.add(mv.Flow)
+		total.add(mv.Flow)
+	}
+	flush()
+
+	return days, total
+}
+
+func buildAccountItem(acc *account, flow accountFlow, baseCurrency string) response.AccountItem {
+	balance := acc.OpeningBalance + flow.net()
+	return response.AccountItem{
+		ID:                    acc.ID,
+		Name:                  acc.Name,
+		Type:                  acc.Type,
+		OpeningBalance:        acc.OpeningBalance,
+		OpeningBalanceDisplay: formatBalance(acc.OpeningBalance, baseCurrency),
+		TotalIncome:           flow.income,
+		TotalExpense:          flow.expense,
+		TotalTransferIn:       flow.transferIn,
+		TotalTransferOut:      flow.transferOut,
+		Balance:               balance,
+		BalanceDisplay:        formatBalance(balance, baseCurrency),
+	}
+}
+
+// formatBalance formats a balance as "Rp 1.000" or "-Rp 1.000"
+func formatBalance(amount float64, baseCurrency string) string {
+	if amount < 0 {
+		return formatAmount(amount, false, baseCurrency)
+	}
+	return strings.Replace(formatAmount(amount, true, baseCurrency), "+", "", 1)
+}
+
+// cellValue returns the raw value of a cell, or nil when the row is too short
+func cellValue(row []interface{}, idx int) interface{} {
+	if idx >= len(row) {
+		return nil
+	}
+	return row[idx]
+}
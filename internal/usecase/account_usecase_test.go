@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRunningBalances(t *testing.T) {
+	at := func(s string) time.Time {
+		tm, err := time.Parse("2006-01-02 15:04", s)
+		if err != nil {
+			t.Fatalf("time.Parse(%q): %v", s, err)
+		}
+		return tm
+	}
+	movements := []accountMovement{
+		{AccountID: "acc_bca", Time: at("2025-01-01 08:00"), Flow: accountFlow{income: 5000000}},
+		{AccountID: DefaultAccountID, Time: at("2025-01-01 09:00"), Flow: accountFlow{expense: 20000}},
+		{AccountID: "acc_bca", Time: at("2025-01-01 12:00"), Flow: accountFlow{expense: 150000}},
+		{AccountID: "acc_bca", Time: at("2025-01-03 10:00"), Flow: accountFlow{transferOut: 500000}},
+		{AccountID: DefaultAccountID, Time: at("2025-01-03 10:00"), Flow: accountFlow{transferIn: 500000}},
+		{AccountID: "acc_bca", Time: at("2025-02-01 07:00"), Flow: accountFlow{expense: 100000}},
+	}
+
+	tests := []struct {
+		name        string
+		opening     float64
+		accountID   string
+		wantDates   []string
+		wantBalance []float64
+		wantTotal   float64
+	}{
+		{
+			name:        "bank account",
+			opening:     1000000,
+			accountID:   "acc_bca",
+			wantDates:   []string{"2025-01-01", "2025-01-03", "2025-02-01"},
+			wantBalance: []float64{5850000, 5350000, 5250000},
+			wantTotal:   4250000,
+		},
+		{
+			name:        "default wallet",
+			opening:     100000,
+			accountID:   DefaultAccountID,
+			wantDates:   []string{"2025-01-01", "2025-01-03"},
+			wantBalance: []float64{80000, 580000},
+			wantTotal:   480000,
+		},
+		{
+			name:      "no movements",
+			opening:   250000,
+			accountID: "acc_ovo",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			days, total := runningBalances(tt.opening, tt.accountID, movements, "IDR")
+			if len(days) != len(tt.wantDates) {
+				t.Fatalf("got %d days, want %d: %+v", len(days), len(tt.wantDates), days)
+			}
+			for i, day := range days {
+				if day.Date != tt.wantDates[i] || day.Balance != tt.wantBalance[i] {
+					t.Errorf("day %d = %s %v, want %s %v", i, day.Date, day.Balance, tt.wantDates[i], tt.wantBalance[i])
+				}
+			}
+			if got := total.net(); got != tt.wantTotal {
+				t.Errorf("total net = %v, want %v", got, tt.wantTotal)
+			}
+		})
+	}
+
+	// The first bank day holds both of its flows
+	days, _ := runningBalances(1000000, "acc_bca", movements, "IDR")
+	if days[0].Income != 5000000 || days[0].Expense != 150000 {
+		t.Errorf("first day flows = %v/%v, want 5000000/150000", days[0].Income, days[0].Expense)
+	}
+}
+
+func TestGetAccountBalancesUnknownAccount(t *testing.T) {
+	repo, _ := newFakeSheetRepo(t, []string{"Januari"}, map[string][][]interface{}{
+		accountSheet + "!A2:E": {{"acc_bca", "BCA", "bank", 1000000}},
+	})
+	u := NewAccountUsecase(repo, "IDR")
+
+	if _, err := u.GetAccountBalances("sheet", "acc_ovo"); !errors.Is(err, ErrAccountNotFound) {
+		t.Errorf("err = %v, want ErrAccountNotFound", err)
+	}
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"math"
+	"time"
+
+	"byeboros-backend/internal/adapter/http/model/response"
+)
+
+// buildCashFlow nets income against expense over [from, to]: net savings, savings rate and a
+// daily balance line starting at zero on the first day, flagging the days it ends below zero
+func buildCashFlow(records []transactionRecord, from, to time.Time, baseCurrency string) response.AnalysisCashFlow {
+	incomeByDay := make(map[string]float64)
+	expenseByDay := make(map[string]float64)
+	end := to.AddDate(0, 0, 1)
+	for _, rec := range records {
+		if rec.Time.Before(from) || !rec.Time.Before(end) {
+			continue
+		}
+		day := rec.Time.Format("2006-01-02")
+		if rec.Type == "income" {
+			incomeByDay[day] += rec.Amount
+		} else {
+			expenseByDay[day] += rec.Amount
+		}
+	}
+
+	cf := response.AnalysisCashFlow{
+		Balance:      make([]response.AnalysisBalancePoint, 0),
+		NegativeDays: make([]response.AnalysisBalancePoint, 0),
+	}
+
+	var balance float64
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		day := d.Format("2006-01-02")
+		income, expense := incomeByDay[day], expenseByDay[day]
+		balance += income - expense
+		cf.TotalIncome += income
+		cf.TotalExpense += expense
+
+		point := response.AnalysisBalancePoint{
+			Date:           day,
+			Income:         income,
+			Expense:        expense,
+			Net:            income - expense,
+			Balance:        balance,
+			BalanceDisplay: formatBalance(balance, baseCurrency),
+		}
+		cf.Balance = append(cf.Balance, point)
+		if balance < 0 {
+			cf.NegativeDays = append(cf.NegativeDays, point)
+		}
+		if d.Equal(from) || balance < cf.LowestBalance {
+			cf.LowestBalance = balance
+		}
+	}
+
+	cf.NetSavings = cf.TotalIncome - cf.TotalExpense
+	if cf.TotalIncome > 0 {
+		cf.SavingsRate = math.Round(cf.NetSavings/cf.TotalIncome*1000) / 10
+	}
+	cf.NetSavingsDisplay = formatBalance(cf.NetSavings, baseCurrency)
+	cf.LowestBalanceDisplay = formatBalance(cf.LowestBalance, baseCurrency)
+	return cf
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildCashFlow(t *testing.T) {
+	at := func(s string) time.Time {
+		t, _ := time.Parse("2006-01-02 15:04", s)
+		return t
+	}
+	records := []transactionRecord{
+		{Type: "expense", Amount: 5000, Time: at("2024-02-29 23:00")}, // before the range
+		{Type: "expense", Amount: 20000, Time: at("2024-03-01 08:00")},
+		{Type: "income", Amount: 100000, Time: at("2024-03-02 09:00")},
+		{Type: "expense", Amount: 30000, Time: at("2024-03-02 12:00")},
+		{Type: "expense", Amount: 10000, Time: at("2024-03-03 23:59")},
+		{Type: "income", Amount: 7000, Time: at("2024-03-04 00:00")}, // after the range
+	}
+
+	cf := buildCashFlow(records, mustDate("2024-03-01"), mustDate("2024-03-03"), "IDR")
+
+	if cf.TotalIncome != 100000 || cf.TotalExpense != 60000 {
+		t.Errorf("totals = %v income, %v expense, want 100000 and 60000", cf.TotalIncome, cf.TotalExpense)
+	}
+	if cf.NetSavings != 40000 {
+		t.Errorf("NetSavings = %v, want 40000", cf.NetSavings)
+	}
+	if cf.SavingsRate != 40 {
+		t.Errorf("SavingsRate = %v, want 40", cf.SavingsRate)
+	}
+	if cf.LowestBalance != -20000 {
+		t.Errorf("LowestBalance = %v, want -20000", cf.LowestBalance)
+	}
+
+	wantBalance := []struct {
+		date    string
+		net     float64
+		balance float64
+	}{
+		{"2024-03-01", -20000, -20000},
+		{"2024-03-02", 70000, 50000},
+		{"2024-03-03", -10000, 40000},
+	}
+	if len(cf.Balance) != len(wantBalance) {
+		t.Fatalf("got %d balance points, want %d", len(cf.Balance), len(wantBalance))
+	}
+	for i, want := range wantBalance {
+		got := cf.Balance[i]
+		if got.Date != want.date || got.Net != want.net || got.Balance != want.balance {
+			t.Errorf("Balance[%d] = %s net %v balance %v, want %s net %v balance %v",
+				i, got.Date, got.Net, got.Balance, want.date, want.net, want.balance)
+		}
+	}
+
+	if len(cf.NegativeDays) != 1 || cf.NegativeDays[0].Date != "2024-03-01" {
+		t.Errorf("NegativeDays = %+v, want only 2024-03-01", cf.NegativeDays)
+	}
+}
+
+func TestBuildCashFlowWithoutIncome(t *testing.T) {
+	records := []transactionRecord{
+		{Type: "expense", Amount: 15000, Time: mustDate("2024-03-05")},
+	}
+
+	cf := buildCashFlow(records, mustDate("2024-03-05"), mustDate("2024-03-05"), "IDR")
+
+	if cf.SavingsRate != 0 {
+		t.Errorf("SavingsRate = %v, want 0 without income", cf.SavingsRate)
+	}
+	if cf.NetSavings != -15000 || cf.LowestBalance != -15000 {
+		t.Errorf("NetSavings = %v, LowestBalance = %v, want -15000", cf.NetSavings, cf.LowestBalance)
+	}
+}
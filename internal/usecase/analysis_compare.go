@@ -0,0 +1,232 @@
+package usecase
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"byeboros-backend/internal/adapter/http/model/response"
+)
+
+// analysisRange returns the dates an analysis request covers: the from/to range for a custom
+// period, the given day for Day, the month of the tab for Month (and for Day without a date,
+// which reports the tab's totals), and the months up to today otherwise. Ranges never reach
+// past today when the period is still running. GetAnalysis uses the same range for every
+// section of its response.
+func (u *TransactionUsecase) analysisRange(spreadsheetID string, sheetName string, period string, date string, fromStr string, toStr string) (time.Time, time.Time, error) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch period {
+	case AnalysisPeriodCustom:
+		return ParseDateRange(fromStr, toStr)
+
+	case "Day", "Month":
+		if period == "Day" && date != "" {
+			day, err := time.Parse("2006-01-02", date)
+			if err != nil {
+				return time.Time{}, time.Time{}, err
+			}
+			return day, day, nil
+		}
+
+		year, err := spreadsheetYear(u.sheetRepo, spreadsheetID)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		month := time.Month(sheetMonth(sheetName))
+		if month == 0 {
+			year, month = now.Year(), now.Month()
+		}
+		from := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+		to := from.AddDate(0, 1, -1)
+		if !from.After(today) && to.After(today) {
+			to = today
+		}
+		return from, to, nil
+	}
+
+	from, to, _ := periodRange(period, now)
+	return from, to, nil
+}
+
+// periodMonths is the length in months of the month-based periods
+var periodMonths = map[string]int{"Month": 1, "3 Months": 3, "6 Months": 6, "Year": 12}
+
+// shiftMonths moves a date back by n months, clamping the day to the end of the target month
+// so that e.g. 31 March becomes 28/29 February rather than 3 March
+func shiftMonths(t time.Time, n int) time.Time {
+	first := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -n, 0)
+	last := first.AddDate(0, 1, -1)
+	if t.Day() > last.Day() {
+		return last
+	}
+	return time.Date(first.Year(), first.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// previousRange returns the comparable period before [from, to]: the same dates a period
+// length earlier for month-based periods, otherwise the same number of days right before.
+// A period ending on the last day of a month is compared with one ending on the last day
+// of the earlier month, so 1-30 April is compared with the whole of March.
+func previousRange(from, to time.Time, period string) (time.Time, time.Time) {
+	if n, ok := periodMonths[period]; ok {
+		prevTo := shiftMonths(to, n)
+		if to.AddDate(0, 0, 1).Day() == 1 {
+			prevTo = time.Date(prevTo.Year(), prevTo.Month()+1, 0, 0, 0, 0, 0, time.UTC)
+		}
+		return shiftMonths(from, n), prevTo
+	}
+	days := int(to.Sub(from).Hours()/24) + 1
+	return from.AddDate(0, 0, -days), from.AddDate(0, 0, -1)
+}
+
+// AddAnalysisComparison runs the analysis of the previous comparable period and attaches the
+// differences to res: total spent and income, and per-category amounts including the
+// categories that only appear in one of the two periods
+func (u *TransactionUsecase) AddAnalysisComparison(spreadsheetID string, sheetName string, period string, date string, fromStr string, toStr string, res *response.AnalysisResponse) error {
+	from, to, err := u.analysisRange(spreadsheetID, sheetName, period, date, fromStr, toStr)
+	if err != nil {
+		return err
+	}
+
+	// Day without a date covers the tab's month, so it is compared like Month
+	rangePeriod := period
+	if period == "Day" && date == "" {
+		rangePeriod = "Month"
+	}
+	prevFrom, prevTo := previousRange(from, to, rangePeriod)
+	prev, err := u.getAnalysisForRange(spreadsheetID, prevFrom, prevTo, AnalysisPeriodCustom)
+	if err != nil {
+		return err
+	}
+
+	// Day and Month totals come from the tab's budget table; rebuild the current period from
+	// the transaction rows like the previous one so both sides list the same categories
+	current := res.Data
+	if _, _, ok := periodRange(period, time.Now()); !ok && period != AnalysisPeriodCustom {
+		rebuilt, err := u.getAnalysisForRange(spreadsheetID, from, to, AnalysisPeriodCustom)
+		if err != nil {
+			return err
+		}
+		current = rebuilt.Data
+	}
+	cmp := &response.AnalysisComparison{
+		PreviousFrom:  prevFrom.Format("2006-01-02"),
+		PreviousTo:    prevTo.Format("2006-01-02"),
+		PreviousLabel: prev.Data.Expense.PeriodLabel,
+		Expense:       buildAnalysisChange(current.Expense.Summary.TotalSpent, prev.Data.Expense.Summary.TotalSpent, false, u.baseCurrency),
+		Income:        buildAnalysisChange(current.Income.Summary.TotalIncome, prev.Data.Income.Summary.TotalIncome, true, u.baseCurrency),
+		Categories:    make([]response.AnalysisCategoryChange, 0),
+
+		NewCategories:         make([]string, 0),
+		DisappearedCategories: make([]string, 0),
+	}
+
+	cmp.Categories = append(cmp.Categories, diffAnalysisCategories("expense", current.Expense.Chart.Categories, prev.Data.Expense.Chart.Categories, u.baseCurrency)...)
+	cmp.Categories = append(cmp.Categories, diffAnalysisCategories("income", current.Income.Chart.Categories, prev.Data.Income.Chart.Categories, u.baseCurrency)...)
+
+	for _, cat := range cmp.Categories {
+		name := cat.Name
+		if cat.SubCategoryName != "" {
+			name = cat.SubCategoryName
+		}
+		switch cat.Status {
+		case "new":
+			cmp.NewCategories = append(cmp.NewCategories, name)
+		case "disappeared":
+			cmp.DisappearedCategories = append(cmp.DisappearedCategories, name)
+		}
+	}
+
+	res.Data.Comparison = cmp
+	return nil
+}
+
+// buildAnalysisChange compares a current total with the previous one. The percentage is 0
+// when there is nothing to compare with.
+func buildAnalysisChange(current, previous float64, isIncome bool, baseCurrency string) response.AnalysisChange {
+	change := response.AnalysisChange{
+		Current:         current,
+		Previous:        previous,
+		Change:          current - previous,
+		PreviousDisplay: formatAmount(previous, isIncome, baseCurrency),
+		ChangeDisplay:   formatBalance(current-previous, baseCurrency),
+	}
+	if previous != 0 {
+		change.ChangePercent = math.Round((current-previous)/previous*1000) / 10
+	}
+	return change
+}
+
+// diffAnalysisCategories pairs the chart categories of both periods. Expense categories are
+// matched on category and sub-category, income categories on name; categories without any
+// amount in either period are left out.
+func diffAnalysisCategories(txnType string, current, previous []response.AnalysisCategory, baseCurrency string) []response.AnalysisCategoryChange {
+	key := func(c response.AnalysisCategory) string {
+		if c.SubCategoryName != "" {
+			return budgetKey(c.CategoryName, c.SubCategoryName)
+		}
+		return budgetKey("", c.Name)
+	}
+
+	changes := make(map[string]*response.AnalysisCategoryChange)
+	var order []string
+	add := func(c response.AnalysisCategory, isCurrent bool) {
+		k := key(c)
+		change, ok := changes[k]
+		if !ok {
+			change = &response.AnalysisCategoryChange{
+				Type:            txnType,
+				Name:            c.Name,
+				CategoryName:    c.CategoryName,
+				SubCategoryName: c.SubCategoryName,
+			}
+			changes[k] = change
+			order = append(order, k)
+		}
+		if isCurrent {
+			change.Current += c.Amount
+		} else {
+			change.Previous += c.Amount
+		}
+	}
+	for _, c := range current {
+		add(c, true)
+	}
+	for _, c := range previous {
+		add(c, false)
+	}
+
+	result := make([]response.AnalysisCategoryChange, 0, len(order))
+	for _, k := range order {
+		change := changes[k]
+		if change.Current == 0 && change.Previous == 0 {
+			continue
+		}
+
+		change.Change = change.Current - change.Previous
+		change.ChangeDisplay = formatBalance(change.Change, baseCurrency)
+		switch {
+		case change.Previous == 0:
+			change.Status = "new"
+		case change.Current == 0:
+			change.Status = "disappeared"
+		case change.Change > 0:
+			change.Status = "increased"
+		case change.Change < 0:
+			change.Status = "decreased"
+		default:
+			change.Status = "unchanged"
+		}
+		if change.Previous != 0 {
+			change.ChangePercent = math.Round(change.Change/change.Previous*1000) / 10
+		}
+		result = append(result, *change)
+	}
+
+	// Largest movements first
+	sort.SliceStable(result, func(i, j int) bool {
+		return math.Abs(result[i].Change) > math.Abs(result[j].Change)
+	})
+	return result
+}
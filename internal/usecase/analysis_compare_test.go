@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+)
+
+func mustDate(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestShiftMonths(t *testing.T) {
+	tests := []struct {
+		in   string
+		n    int
+		want string
+	}{
+		{"2024-05-15", 1, "2024-04-15"},
+		{"2024-03-31", 1, "2024-02-29"},
+		{"2023-03-31", 1, "2023-02-28"},
+		{"2024-05-31", 1, "2024-04-30"},
+		{"2024-01-10", 1, "2023-12-10"},
+		{"2024-02-29", 12, "2023-02-28"},
+		{"2024-08-31", 6, "2024-02-29"},
+	}
+	for _, tt := range tests {
+		if got := shiftMonths(mustDate(tt.in), tt.n).Format("2006-01-02"); got != tt.want {
+			t.Errorf("shiftMonths(%s, %d) = %s, want %s", tt.in, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestPreviousRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to string
+		period   string
+		wantFrom string
+		wantTo   string
+	}{
+		{"month", "2024-03-01", "2024-03-31", "Month", "2024-02-01", "2024-02-29"},
+		{"month across new year", "2024-01-01", "2024-01-31", "Month", "2023-12-01", "2023-12-31"},
+		{"month after a longer month", "2024-04-01", "2024-04-30", "Month", "2024-03-01", "2024-03-31"},
+		{"month to date", "2024-04-01", "2024-04-15", "Month", "2024-03-01", "2024-03-15"},
+		{"three months", "2024-04-01", "2024-06-30", "3 Months", "2024-01-01", "2024-03-31"},
+		{"six months", "2024-07-01", "2024-12-31", "6 Months", "2024-01-01", "2024-06-30"},
+		{"year", "2024-01-01", "2024-12-31", "Year", "2023-01-01", "2023-12-31"},
+		{"day", "2024-03-01", "2024-03-01", "Day", "2024-02-29", "2024-02-29"},
+		{"week", "2024-03-04", "2024-03-10", "Week", "2024-02-26", "2024-03-03"},
+		{"custom", "2024-03-10", "2024-03-19", "Custom", "2024-02-29", "2024-03-09"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to := previousRange(mustDate(tt.from), mustDate(tt.to), tt.period)
+			if got := from.Format("2006-01-02"); got != tt.wantFrom {
+				t.Errorf("from = %s, want %s", got, tt.wantFrom)
+			}
+			if got := to.Format("2006-01-02"); got != tt.wantTo {
+				t.Errorf("to = %s, want %s", got, tt.wantTo)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"byeboros-backend/internal/adapter/http/model/response"
+)
+
+// heatmapDays labels the heatmap rows, starting on Monday
+var heatmapDays = []string{"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday"}
+
+// heatmapRow returns the row of a weekday in a Monday-first heatmap
+func heatmapRow(day time.Weekday) int {
+	return (int(day) + 6) % 7
+}
+
+func newHeatmapGrid() [][]response.HeatmapCell {
+	grid := make([][]response.HeatmapCell, len(heatmapDays))
+	for i := range grid {
+		grid[i] = make([]response.HeatmapCell, 24)
+	}
+	return grid
+}
+
+// heatmapPeak returns the cell with the highest amount
+func heatmapPeak(grid [][]response.HeatmapCell, baseCurrency string) *response.HeatmapPeak {
+	var peak *response.HeatmapPeak
+	for d, row := range grid {
+		for h, cell := range row {
+			if cell.Amount > 0 && (peak == nil || cell.Amount > peak.Amount) {
+				peak = &response.HeatmapPeak{Day: heatmapDays[d], Hour: h, Count: cell.Count, Amount: cell.Amount}
+			}
+		}
+	}
+	if peak != nil {
+		peak.AmountDisplay = formatBalance(peak.Amount, baseCurrency)
+	}
+	return peak
+}
+
+// GetHeatmapAnalysis counts and sums the expenses of a date range per day of week (rows,
+// Monday first) and hour of day (columns), from the transaction timestamps. With byCategory
+// every sub-category also gets its own grid.
+func (u *TransactionUsecase) GetHeatmapAnalysis(spreadsheetID string, fromStr string, toStr string, categoryFilter string, byCategory bool) (*response.HeatmapResponse, error) {
+	from, to, err := ParseDateRange(fromStr, toStr)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := loadRecordsInRange(u.sheetRepo, spreadsheetID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &response.HeatmapResponse{
+		From:  from.Format("2006-01-02"),
+		To:    to.Format("2006-01-02"),
+		Days:  heatmapDays,
+		Cells: newHeatmapGrid(),
+	}
+
+	categories := make(map[string]*response.HeatmapCategory)
+	for _, rec := range records {
+		if rec.Type != "expense" {
+			continue
+		}
+		if categoryFilter != "" && !strings.EqualFold(rec.Category, categoryFilter) {
+			continue
+		}
+
+		d, h := heatmapRow(rec.Time.Weekday()), rec.Time.Hour()
+		res.Cells[d][h].Count++
+		res.Cells[d][h].Amount += rec.Amount
+		res.TotalCount++
+		res.TotalAmount += rec.Amount
+
+		key := strings.ToLower(rec.Category)
+		cat, ok := categories[key]
+		if !ok {
+			cat = &response.HeatmapCategory{Category: rec.Category}
+			if byCategory {
+				cat.Cells = newHeatmapGrid()
+			}
+			categories[key] = cat
+		}
+		cat.Count++
+		cat.Amount += rec.Amount
+		if byCategory {
+			cat.Cells[d][h].Count++
+			cat.Cells[d][h].Amount += rec.Amount
+		}
+	}
+
+	res.TotalAmountDisplay = formatBalance(res.TotalAmount, u.baseCurrency)
+	res.Peak = heatmapPeak(res.Cells, u.baseCurrency)
+
+	res.Categories = make([]response.HeatmapCategory, 0, len(categories))
+	for _, cat := range categories {
+		cat.AmountDisplay = formatBalance(cat.Amount, u.baseCurrency)
+		if byCategory {
+			cat.Peak = heatmapPeak(cat.Cells, u.baseCurrency)
+		}
+		res.Categories = append(res.Categories, *cat)
+	}
+	sort.SliceStable(res.Categories, func(i, j int) bool {
+		if res.Categories[i].Amount == res.Categories[j].Amount {
+			return res.Categories[i].Category < res.Categories[j].Category
+		}
+		return res.Categories[i].Amount > res.Categories[j].Amount
+	})
+	return res, nil
+}
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"byeboros-backend/internal/adapter/http/model/response"
+)
+
+func TestHeatmapRow(t *testing.T) {
+	tests := map[time.Weekday]int{
+		time.Monday:   0,
+		time.Saturday: 5,
+		time.Sunday:   6,
+	}
+	for day, want := range tests {
+		if got := heatmapRow(day); got != want {
+			t.Errorf("heatmapRow(%s) = %d, want %d", day, got, want)
+		}
+		if heatmapDays[want] != day.String() {
+			t.Errorf("heatmapDays[%d] = %s, want %s", want, heatmapDays[want], day)
+		}
+	}
+}
+
+func TestHeatmapPeak(t *testing.T) {
+	if peak := heatmapPeak(newHeatmapGrid(), "IDR"); peak != nil {
+		t.Errorf("empty grid peak = %+v, want nil", peak)
+	}
+
+	grid := newHeatmapGrid()
+	grid[0][12] = response.HeatmapCell{Count: 3, Amount: 90000}
+	grid[4][19] = response.HeatmapCell{Count: 1, Amount: 250000}
+	grid[6][8] = response.HeatmapCell{Count: 2, Amount: 250000} // a tie keeps the earlier cell
+
+	peak := heatmapPeak(grid, "IDR")
+	if peak == nil || peak.Day != "Friday" || peak.Hour != 19 || peak.Count != 1 || peak.Amount != 250000 {
+		t.Errorf("peak = %+v, want Friday 19:00 with 250000", peak)
+	}
+}
+
+func TestGetHeatmapAnalysisBuckets(t *testing.T) {
+	repo, _ := newFakeSheetRepo(t, []string{"Maret"}, map[string][][]interface{}{
+		yearSpreadsheetSheet + "!A2:B": {{2024, "sheet-id"}},
+		"Maret!A2:G": {
+			{"Makan siang", "Makan", "Kebutuhan", 30000, "", "3/4/2024 12:05:00"}, // Monday
+			{"Makan siang", "makan", "Kebutuhan", 45000, "", "3/11/2024 12:59:00"},
+			{"Bioskop", "Hiburan", "Keinginan", 60000, "", "3/10/2024 21:30:00"}, // Sunday
+			{"Kopi", "Makan", "Keinginan", 25000, "", "3/31/2024 7:00:00"},       // outside the range
+		},
+		"Maret!I2:N": {
+			{"Gaji", "Gaji", 8000000, "", "3/4/2024 12:00:00"},
+		},
+	})
+	uc := NewTransactionUsecase(repo, nil, "IDR")
+
+	tests := []struct {
+		name           string
+		category       string
+		byCategory     bool
+		wantCount      int
+		wantAmount     float64
+		wantCategories int
+	}{
+		{"all expenses", "", false, 3, 135000, 2},
+		{"one sub-category", "MAKAN", false, 2, 75000, 1},
+		{"per category grids", "", true, 3, 135000, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := uc.GetHeatmapAnalysis("sheet-id", "2024-03-01", "2024-03-30", tt.category, tt.byCategory)
+			if err != nil {
+				t.Fatalf("GetHeatmapAnalysis: %v", err)
+			}
+			if res.TotalCount != tt.wantCount || res.TotalAmount != tt.wantAmount {
+				t.Errorf("totals = %d/%v, want %d/%v", res.TotalCount, res.TotalAmount, tt.wantCount, tt.wantAmount)
+			}
+			if got := res.Cells[0][12]; got.Count != 2 || got.Amount != 75000 {
+				t.Errorf("Monday 12:00 = %+v, want both lunches", got)
+			}
+			if res.Peak == nil || res.Peak.Day != "Monday" || res.Peak.Hour != 12 {
+				t.Errorf("peak = %+v, want Monday 12:00", res.Peak)
+			}
+			if len(res.Categories) != tt.wantCategories {
+				t.Fatalf("got %d categories, want %d: %+v", len(res.Categories), tt.wantCategories, res.Categories)
+			}
+			// Categories are sorted by amount; case variants of a sub-category share a bucket
+			if top := res.Categories[0]; tt.wantCategories == 2 && (top.Category != "Makan" || top.Amount != 75000) {
+				t.Errorf("top category = %+v, want Makan with 75000", top)
+			}
+			for _, cat := range res.Categories {
+				if (cat.Cells != nil) != tt.byCategory || (cat.Peak != nil) != tt.byCategory {
+					t.Errorf("category %s grid present = %v, want %v", cat.Category, cat.Cells != nil, tt.byCategory)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"byeboros-backend/internal/adapter/http/model/response"
+)
+
+// unknownMember groups the rows without a creator email
+const unknownMember = "unknown"
+
+// GetMemberAnalysis groups the income and expense of a date range by the household member who
+// entered them (the creator email in column G/N): totals, categories and the priority
+// distribution of each member's expenses
+func (u *TransactionUsecase) GetMemberAnalysis(spreadsheetID string, fromStr string, toStr string) (*response.MemberAnalysisResponse, error) {
+	from, to, err := ParseDateRange(fromStr, toStr)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := loadRecordsInRange(u.sheetRepo, spreadsheetID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	type memberTotals struct {
+		item       response.MemberAnalysisItem
+		categories map[string]*response.MemberAnalysisCategory
+		priorities map[string]float64
+	}
+	members := make(map[string]*memberTotals)
+
+	var totalExpense float64
+	for _, rec := range records {
+		email := strings.ToLower(strings.TrimSpace(rec.CreatedBy))
+		if email == "" {
+			email = unknownMember
+		}
+
+		m, ok := members[email]
+		if !ok {
+			m = &memberTotals{
+				item:       response.MemberAnalysisItem{Member: email},
+				categories: make(map[string]*response.MemberAnalysisCategory),
+				priorities: make(map[string]float64),
+			}
+			members[email] = m
+		}
+
+		if rec.Type == "income" {
+			m.item.TotalIncome += rec.Amount
+		} else {
+			m.item.TotalExpense += rec.Amount
+			totalExpense += rec.Amount
+			if key := canonicalPriority(rec.Priority); key != "" {
+				m.priorities[key] += rec.Amount
+			}
+		}
+		m.item.TransactionCount++
+
+		key := rec.Type + "|" + strings.ToLower(rec.Category)
+		cat, ok := m.categories[key]
+		if !ok {
+			cat = &response.MemberAnalysisCategory{Type: rec.Type, Category: rec.Category}
+			m.categories[key] = cat
+		}
+		cat.Amount += rec.Amount
+		cat.Count++
+	}
+
+	res := &response.MemberAnalysisResponse{
+		From:    from.Format("2006-01-02"),
+		To:      to.Format("2006-01-02"),
+		Members: make([]response.MemberAnalysisItem, 0, len(members)),
+	}
+	for _, m := range members {
+		item := m.item
+		item.TotalExpenseDisplay = formatAmount(item.TotalExpense, false, u.baseCurrency)
+		item.TotalIncomeDisplay = formatAmount(item.TotalIncome, true, u.baseCurrency)
+		if totalExpense > 0 {
+			item.ExpenseShare = math.Round(item.TotalExpense/totalExpense*1000) / 10
+		}
+		item.PriorityDistribution = buildPriorityDistribution(m.priorities, u.baseCurrency)
+
+		item.Categories = make([]response.MemberAnalysisCategory, 0, len(m.categories))
+		for _, cat := range m.categories {
+			cat.AmountDisplay = formatAmount(cat.Amount, cat.Type == "income", u.baseCurrency)
+			item.Categories = append(item.Categories, *cat)
+		}
+		sort.SliceStable(item.Categories, func(i, j int) bool {
+			if item.Categories[i].Type != item.Categories[j].Type {
+				return item.Categories[i].Type < item.Categories[j].Type
+			}
+			return item.Categories[i].Amount > item.Categories[j].Amount
+		})
+		res.Members = append(res.Members, item)
+	}
+
+	sort.SliceStable(res.Members, func(i, j int) bool {
+		if res.Members[i].TotalExpense == res.Members[j].TotalExpense {
+			return res.Members[i].Member < res.Members[j].Member
+		}
+		return res.Members[i].TotalExpense > res.Members[j].TotalExpense
+	})
+	return res, nil
+}
@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"testing"
+
+	"byeboros-backend/internal/adapter/http/model/response"
+)
+
+func TestGetMemberAnalysis(t *testing.T) {
+	repo, _ := newFakeSheetRepo(t, []string{"Maret"}, map[string][][]interface{}{
+		yearSpreadsheetSheet + "!A2:B": {{2024, "sheet-id"}},
+		"Maret!A2:G": {
+			{"Belanja bulanan", "Makan", "Tinggi", 600000, "", "3/2/2024 10:00:00", "ani@example.com"},
+			{"Makan malam", "makan", "rendah", 150000, "", "3/5/2024 19:00:00", " Ani@Example.com "},
+			{"Bensin", "Transportasi", "Sedang", 200000, "", "3/6/2024 8:00:00", "budi@example.com"},
+			{"Parkir", "Transportasi", "", 50000, "", "3/7/2024 8:00:00"},
+			{"Servis", "Transportasi", "Tinggi", 500000, "", "4/1/2024 8:00:00", "budi@example.com"}, // outside the range
+		},
+		"Maret!I2:N": {
+			{"Gaji", "Gaji", 8000000, "", "3/1/2024 9:00:00", "budi@example.com"},
+		},
+	})
+
+	res, err := NewTransactionUsecase(repo, nil, "IDR").GetMemberAnalysis("sheet-id", "2024-03-01", "2024-03-31")
+	if err != nil {
+		t.Fatalf("GetMemberAnalysis: %v", err)
+	}
+
+	// Members are sorted by expense, emails compared case-insensitively
+	tests := []struct {
+		member     string
+		expense    float64
+		income     float64
+		share      float64
+		count      int
+		categories int
+		priorities map[string]float64
+	}{
+		{"ani@example.com", 750000, 0, 75, 2, 1, map[string]float64{"high": 600000, "low": 150000}},
+		{"budi@example.com", 200000, 8000000, 20, 2, 2, map[string]float64{"medium": 200000}},
+		{unknownMember, 50000, 0, 5, 1, 1, map[string]float64{}},
+	}
+	if len(res.Members) != len(tests) {
+		t.Fatalf("got %d members, want %d: %+v", len(res.Members), len(tests), res.Members)
+	}
+	for i, tt := range tests {
+		m := res.Members[i]
+		if m.Member != tt.member {
+			t.Errorf("member %d = %s, want %s", i, m.Member, tt.member)
+			continue
+		}
+		if m.TotalExpense != tt.expense || m.TotalIncome != tt.income || m.ExpenseShare != tt.share || m.TransactionCount != tt.count {
+			t.Errorf("%s = %v/%v/%v%%/%d, want %v/%v/%v%%/%d", tt.member,
+				m.TotalExpense, m.TotalIncome, m.ExpenseShare, m.TransactionCount, tt.expense, tt.income, tt.share, tt.count)
+		}
+		if len(m.Categories) != tt.categories {
+			t.Errorf("%s categories = %+v, want %d", tt.member, m.Categories, tt.categories)
+		}
+		for _, p := range m.PriorityDistribution {
+			if p.Amount != tt.priorities[p.Level] {
+				t.Errorf("%s priority %s = %v, want %v", tt.member, p.Level, p.Amount, tt.priorities[p.Level])
+			}
+		}
+	}
+
+	// Income is listed after expense within a member
+	budi := res.Members[1].Categories
+	if budi[0] != (response.MemberAnalysisCategory{Type: "expense", Category: "Transportasi", Amount: 200000, AmountDisplay: "-Rp 200.000", Count: 1}) ||
+		budi[1].Type != "income" || budi[1].Amount != 8000000 {
+		t.Errorf("budi categories = %+v, want Transportasi then Gaji", budi)
+	}
+}
@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"byeboros-backend/internal/adapter/http/model/response"
+)
+
+// priorityTopLimit caps the sub-categories listed per priority
+const priorityTopLimit = 5
+
+// GetPriorityAnalysis reports how expenses split over the priority levels: each level's share
+// per day, week or month, its top sub-categories, and how much cutting low-priority spending
+// by cutPercent would have saved over the range
+func (u *TransactionUsecase) GetPriorityAnalysis(spreadsheetID string, fromStr string, toStr string, interval string, cutPercent float64) (*response.PriorityAnalysisResponse, error) {
+	from, to, err := ParseDateRange(fromStr, toStr)
+	if err != nil {
+		return nil, err
+	}
+	if interval == "" {
+		interval = "month"
+	}
+
+	records, err := loadRecordsInRange(u.sheetRepo, spreadsheetID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &response.PriorityAnalysisResponse{
+		From:     from.Format("2006-01-02"),
+		To:       to.Format("2006-01-02"),
+		Interval: interval,
+		Periods:  make([]response.PriorityPeriod, 0),
+		Levels:   make([]response.PriorityLevel, 0, len(priorityOrder)),
+	}
+
+	index := make(map[string]int)
+	for start := trendBucketStart(from, interval); !start.After(to); start = trendBucketNext(start, interval) {
+		if len(res.Periods) >= maxTrendPoints {
+			return nil, fmt.Errorf("range is too long for a %s priority report, use a larger interval", interval)
+		}
+		period := response.PriorityPeriod{
+			Start:   start.Format("2006-01-02"),
+			Label:   trendBucketLabel(start, interval),
+			Amounts: make(map[string]float64),
+			Shares:  make(map[string]float64),
+		}
+		for _, level := range priorityOrder {
+			period.Amounts[level] = 0
+			period.Shares[level] = 0
+		}
+		index[start.Format("2006-01-02")] = len(res.Periods)
+		res.Periods = append(res.Periods, period)
+	}
+
+	totals := make(map[string]float64)
+	subCategories := make(map[string]map[string]*response.PrioritySubCategory)
+	for _, key := range priorityOrder {
+		subCategories[key] = make(map[string]*response.PrioritySubCategory)
+	}
+
+	var totalExpense float64
+	for _, rec := range records {
+		if rec.Type == "income" {
+			res.TotalIncome += rec.Amount
+			continue
+		}
+		totalExpense += rec.Amount
+
+		level := canonicalPriority(rec.Priority)
+		if level == "" {
+			res.Unprioritized += rec.Amount
+			continue
+		}
+		totals[level] += rec.Amount
+
+		if i, ok := index[trendBucketStart(rec.Time, interval).Format("2006-01-02")]; ok {
+			res.Periods[i].Amounts[level] += rec.Amount
+			res.Periods[i].Total += rec.Amount
+		}
+
+		key := strings.ToLower(rec.Category)
+		sub, ok := subCategories[level][key]
+		if !ok {
+			sub = &response.PrioritySubCategory{SubCategoryName: rec.Category}
+			subCategories[level][key] = sub
+		}
+		sub.Amount += rec.Amount
+		sub.Count++
+	}
+
+	for i := range res.Periods {
+		p := &res.Periods[i]
+		for _, level := range priorityOrder {
+			if p.Total > 0 {
+				p.Shares[level] = math.Round(p.Amounts[level]/p.Total*1000) / 10
+			}
+		}
+	}
+
+	var prioritized float64
+	for _, level := range priorityOrder {
+		prioritized += totals[level]
+	}
+	for _, level := range priorityOrder {
+		item := response.PriorityLevel{
+			Level:         level,
+			Label:         priorityLabels[level],
+			Amount:        totals[level],
+			AmountDisplay: formatBalance(totals[level], u.baseCurrency),
+			SubCategories: make([]response.PrioritySubCategory, 0),
+		}
+		if prioritized > 0 {
+			item.Share = math.Round(totals[level]/prioritized*1000) / 10
+		}
+		for _, sub := range subCategories[level] {
+			if totals[level] > 0 {
+				sub.Percent = math.Round(sub.Amount/totals[level]*1000) / 10
+			}
+			sub.AmountDisplay = formatBalance(sub.Amount, u.baseCurrency)
+			item.SubCategories = append(item.SubCategories, *sub)
+		}
+		sort.SliceStable(item.SubCategories, func(i, j int) bool {
+			if item.SubCategories[i].Amount == item.SubCategories[j].Amount {
+				return item.SubCategories[i].SubCategoryName < item.SubCategories[j].SubCategoryName
+			}
+			return item.SubCategories[i].Amount > item.SubCategories[j].Amount
+		})
+		if len(item.SubCategories) > priorityTopLimit {
+			item.SubCategories = item.SubCategories[:priorityTopLimit]
+		}
+		res.Levels = append(res.Levels, item)
+	}
+	res.UnprioritizedDisplay = formatBalance(res.Unprioritized, u.baseCurrency)
+
+	res.WhatIf = buildPriorityWhatIf(totals["low"], cutPercent, res.TotalIncome, totalExpense, from, to, u.baseCurrency)
+	return res, nil
+}
+
+// buildPriorityWhatIf computes what cutting low-priority spending by cutPercent would have
+// saved over [from, to], per month (a range shorter than a month counts as one) and in
+// savings rate
+func buildPriorityWhatIf(lowPriority, cutPercent, totalIncome, totalExpense float64, from, to time.Time, baseCurrency string) response.PriorityWhatIf {
+	savings := math.Round(lowPriority * cutPercent / 100)
+	months := (to.Sub(from).Hours()/24 + 1) / (365.25 / 12)
+	whatIf := response.PriorityWhatIf{
+		CutPercent:     cutPercent,
+		LowPriority:    lowPriority,
+		Savings:        savings,
+		SavingsDisplay: formatBalance(savings, baseCurrency),
+	}
+	if months > 0 {
+		whatIf.MonthlySavings = math.Round(savings / math.Max(months, 1))
+	}
+	whatIf.MonthlySavingsDisplay = formatBalance(whatIf.MonthlySavings, baseCurrency)
+	if totalIncome > 0 {
+		whatIf.CurrentSavingsRate = math.Round((totalIncome-totalExpense)/totalIncome*1000) / 10
+		whatIf.NewSavingsRate = math.Round((totalIncome-totalExpense+savings)/totalIncome*1000) / 10
+	}
+	return whatIf
+}
@@ -0,0 +1,91 @@
+package usecase
+
+import "testing"
+
+func TestBuildPriorityWhatIf(t *testing.T) {
+	tests := []struct {
+		name            string
+		lowPriority     float64
+		cutPercent      float64
+		income          float64
+		from, to        string
+		wantSavings     float64
+		wantMonthly     float64
+		wantCurrentRate float64
+		wantNewRate     float64
+	}{
+		{"one month", 1000000, 50, 8000000, "2024-03-01", "2024-03-31", 500000, 490927, 25, 31.3},
+		{"shorter than a month counts as one", 1000000, 50, 8000000, "2024-03-01", "2024-03-10", 500000, 500000, 25, 31.3},
+		{"a full year", 1000000, 50, 8000000, "2024-01-01", "2024-12-31", 500000, 41581, 25, 31.3},
+		{"savings are rounded", 333333, 10, 8000000, "2024-03-01", "2024-03-10", 33333, 33333, 25, 25.4},
+		{"no cut", 1000000, 0, 8000000, "2024-03-01", "2024-03-31", 0, 0, 25, 25},
+		{"no income leaves the rates at zero", 1000000, 50, 0, "2024-03-01", "2024-03-10", 500000, 500000, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildPriorityWhatIf(tt.lowPriority, tt.cutPercent, tt.income, 6000000, mustDate(tt.from), mustDate(tt.to), "IDR")
+			if got.LowPriority != tt.lowPriority || got.CutPercent != tt.cutPercent {
+				t.Errorf("inputs = %v/%v, want %v/%v", got.LowPriority, got.CutPercent, tt.lowPriority, tt.cutPercent)
+			}
+			if got.Savings != tt.wantSavings || got.MonthlySavings != tt.wantMonthly {
+				t.Errorf("savings = %v, monthly %v, want %v, monthly %v", got.Savings, got.MonthlySavings, tt.wantSavings, tt.wantMonthly)
+			}
+			if got.CurrentSavingsRate != tt.wantCurrentRate || got.NewSavingsRate != tt.wantNewRate {
+				t.Errorf("savings rate = %v -> %v, want %v -> %v", got.CurrentSavingsRate, got.NewSavingsRate, tt.wantCurrentRate, tt.wantNewRate)
+			}
+		})
+	}
+}
+
+func TestGetPriorityAnalysis(t *testing.T) {
+	repo, _ := newFakeSheetRepo(t, []string{"Januari", "Februari"}, map[string][][]interface{}{
+		yearSpreadsheetSheet + "!A2:B": {{2024, "sheet-id"}},
+		"Januari!A2:G": {
+			{"Belanja bulanan", "Makan", "Tinggi", 600000, "", "1/5/2024 10:00:00"},
+			{"Bioskop", "Hiburan", "rendah", 200000, "", "1/6/2024 20:00:00"},
+			{"Jajan", "Makan", "Low", 200000, "", "1/7/2024 15:00:00"},
+		},
+		"Januari!I2:N": {{"Gaji", "Gaji", 4000000, "", "1/1/2024 9:00:00"}},
+		"Februari!A2:G": {
+			{"Bensin", "Transportasi", "Sedang", 300000, "", "2/2/2024 8:00:00"},
+			{"Parkir", "Transportasi", "", 100000, "", "2/3/2024 8:00:00"},
+		},
+	})
+
+	res, err := NewTransactionUsecase(repo, nil, "IDR").GetPriorityAnalysis("sheet-id", "2024-01-01", "2024-02-29", "", 50)
+	if err != nil {
+		t.Fatalf("GetPriorityAnalysis: %v", err)
+	}
+
+	if res.Interval != "month" || len(res.Periods) != 2 {
+		t.Fatalf("interval %s with %d periods, want month with 2", res.Interval, len(res.Periods))
+	}
+	jan := res.Periods[0]
+	if jan.Total != 1000000 || jan.Shares["high"] != 60 || jan.Shares["low"] != 40 || jan.Shares["medium"] != 0 {
+		t.Errorf("January = %v with shares %v, want 1000000 split 60/0/40", jan.Total, jan.Shares)
+	}
+	// Unprioritized spending is reported on its own, outside the shares
+	if feb := res.Periods[1]; feb.Total != 300000 || feb.Shares["medium"] != 100 {
+		t.Errorf("February = %v with shares %v, want 300000 all medium", feb.Total, feb.Shares)
+	}
+	if res.Unprioritized != 100000 {
+		t.Errorf("Unprioritized = %v, want 100000", res.Unprioritized)
+	}
+
+	for _, level := range res.Levels {
+		if level.Level != "low" {
+			continue
+		}
+		if level.Amount != 400000 || level.Share != 30.8 || len(level.SubCategories) != 2 {
+			t.Errorf("low = %v (%v%%) in %+v, want 400000 (30.8%%) in 2 sub-categories", level.Amount, level.Share, level.SubCategories)
+		}
+		if sub := level.SubCategories[0]; sub.SubCategoryName != "Hiburan" || sub.Percent != 50 {
+			t.Errorf("first low sub-category = %+v, want Hiburan at 50%% (ties sorted by name)", sub)
+		}
+	}
+
+	// Half of the 400000 low-priority spending, over the 60 days of January and February
+	if w := res.WhatIf; w.Savings != 200000 || w.MonthlySavings != 101458 || w.CurrentSavingsRate != 65 || w.NewSavingsRate != 70 {
+		t.Errorf("what-if = %+v, want 200000 saved, 101458 a month, 65%% -> 70%%", w)
+	}
+}
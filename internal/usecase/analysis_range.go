@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"byeboros-backend/internal/adapter/http/model/response"
+)
+
+// AnalysisPeriodCustom is the period of an analysis over an explicit from/to range
+const AnalysisPeriodCustom = "Custom"
+
+// uncategorizedExpense groups expenses whose sub-category is missing from every budget table
+const uncategorizedExpense = "Uncategorized"
+
+// periodRange returns the date range of a multi-month period, ending today. Day and Month
+// only read the current tab, so they have no range.
+func periodRange(period string, now time.Time) (time.Time, time.Time, bool) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	firstOfMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	switch period {
+	case "3 Months":
+		return firstOfMonth.AddDate(0, -2, 0), today, true
+	case "6 Months":
+		return firstOfMonth.AddDate(0, -5, 0), today, true
+	case "Year":
+		return time.Date(now.Year(), 1, 1, 0, 0, 0, 0, time.UTC), today, true
+	}
+	return time.Time{}, time.Time{}, false
+}
+
+// GetRangeAnalysis returns the analysis of an arbitrary from/to range (yyyy-MM-dd), which
+// may span several months and years
+func (u *TransactionUsecase) GetRangeAnalysis(spreadsheetID string, fromStr string, toStr string) (*response.AnalysisResponse, error) {
+	from, to, err := ParseDateRange(fromStr, toStr)
+	if err != nil {
+		return nil, err
+	}
+	return u.getAnalysisForRange(spreadsheetID, from, to, AnalysisPeriodCustom)
+}
+
+// getAnalysisForRange builds the analysis from the transaction rows of every month tab in the
+// range, keeping only rows dated within it. Each tab is read from the spreadsheet of its
+// year, so a range crossing a new year never mixes up months of different years.
+func (u *TransactionUsecase) getAnalysisForRange(spreadsheetID string, from, to time.Time, period string) (*response.AnalysisResponse, error) {
+	tabs, err := monthTabsInRange(u.sheetRepo, spreadsheetID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := readMonthTabs(u.sheetRepo, tabs, "A2:G", "I2:N", "P2:T")
+	if err != nil {
+		return nil, err
+	}
+	records := recordsInRange(tabs, values, from, to)
+
+	masterIncRows, err := u.sheetRepo.GetRangeValues(spreadsheetID, masterDataSheet+"!H4:H")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch master income categories: %w", err)
+	}
+
+	// The budget tables give the category of each sub-category; later months win when a
+	// sub-category moved between categories
+	type subCategory struct{ category, name string }
+	subCategories := make(map[string]subCategory)
+	var order []string
+	for i := range tabs {
+		for _, row := range values[i][2] {
+			catName := cellString(row, 0)
+			subCatName := cellString(row, 1)
+			if catName == "" || strings.EqualFold(catName, "Nama Kategori") || strings.EqualFold(catName, "Category") {
+				continue
+			}
+			key := strings.ToLower(subCatName)
+			if _, ok := subCategories[key]; !ok {
+				order = append(order, key)
+			}
+			subCategories[key] = subCategory{catName, subCatName}
+		}
+	}
+
+	// Rebuild the rows the analysis builders expect from the filtered records: P2:T with the
+	// spent amount in the Alokasi column, A2:G and I2:N with only the columns they read
+	spent := make(map[string]float64)
+	var expenseRows, incomeRows [][]interface{}
+	for _, rec := range records {
+		if rec.Type == "income" {
+			incomeRows = append(incomeRows, []interface{}{rec.Description, rec.Category, rec.Amount})
+			continue
+		}
+		expenseRows = append(expenseRows, []interface{}{rec.Description, rec.Category, rec.Priority, rec.Amount})
+
+		key := strings.ToLower(rec.Category)
+		if _, ok := subCategories[key]; !ok {
+			subCategories[key] = subCategory{uncategorizedExpense, rec.Category}
+			order = append(order, key)
+		}
+		spent[key] += rec.Amount
+	}
+
+	budgetRows := make([][]interface{}, 0, len(order))
+	for _, key := range order {
+		sc := subCategories[key]
+		budgetRows = append(budgetRows, []interface{}{sc.category, sc.name, 0, spent[key]})
+	}
+
+	expenseData := u.getExpenseAnalysis(nil, budgetRows, expenseRows, period)
+	incomeData := u.getIncomeAnalysis(nil, incomeRows, masterIncRows, period)
+
+	if period == AnalysisPeriodCustom {
+		label := from.Format("Jan 2, 2006") + " - " + to.Format("Jan 2, 2006")
+		days := rangeDays(from, to, time.Now())
+		expenseData.PeriodLabel = label
+		incomeData.PeriodLabel = label
+		expenseData.DailyAverage.Amount = expenseData.Summary.TotalSpent / days
+		expenseData.DailyAverage.AmountDisplay = strings.Replace(formatAmount(expenseData.DailyAverage.Amount, false, u.baseCurrency), "-", "", 1)
+		incomeData.DailyAverage.Amount = incomeData.Summary.TotalIncome / days
+		incomeData.DailyAverage.AmountDisplay = strings.Replace(formatAmount(incomeData.DailyAverage.Amount, true, u.baseCurrency), "+", "", 1)
+	}
+
+	currencies, err := u.getCurrencyTotals(spreadsheetID, records)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.AnalysisResponse{
+		Status: "success",
+		Data: response.AnalysisData{
+			From:         from.Format("2006-01-02"),
+			To:           to.Format("2006-01-02"),
+			BaseCurrency: u.baseCurrency,
+			Expense:      expenseData,
+			Income:       incomeData,
+			Currencies:   currencies,
+			CashFlow:     buildCashFlow(records, from, to, u.baseCurrency),
+		},
+	}, nil
+}
+
+// rangeDays counts the days of [from, to] used for daily averages. Days after today have
+// no transactions yet, so a range reaching into the future is only counted up to today.
+func rangeDays(from, to time.Time, now time.Time) float64 {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if to.After(today) && !from.After(today) {
+		to = today
+	}
+	return to.Sub(from).Hours()/24 + 1
+}
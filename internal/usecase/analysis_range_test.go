@@ -0,0 +1,47 @@
+package usecase
+
+import "testing"
+
+func TestAnalysisRangeDayWithoutDateCoversTheTab(t *testing.T) {
+	repo, _ := newFakeSheetRepo(t, []string{masterDataSheet, "Februari"}, map[string][][]interface{}{
+		yearSpreadsheetSheet + "!A2:B": {{2023, "sheet-id"}},
+	})
+	uc := NewTransactionUsecase(repo, nil, "IDR")
+
+	tests := []struct {
+		period   string
+		date     string
+		wantFrom string
+		wantTo   string
+	}{
+		{"Day", "", "2023-02-01", "2023-02-28"},
+		{"Month", "", "2023-02-01", "2023-02-28"},
+		{"Day", "2023-02-14", "2023-02-14", "2023-02-14"},
+	}
+	for _, tt := range tests {
+		from, to, err := uc.analysisRange("sheet-id", "Februari", tt.period, tt.date, "", "")
+		if err != nil {
+			t.Fatalf("analysisRange(%s, %q): %v", tt.period, tt.date, err)
+		}
+		if got := from.Format("2006-01-02") + ".." + to.Format("2006-01-02"); got != tt.wantFrom+".."+tt.wantTo {
+			t.Errorf("analysisRange(%s, %q) = %s, want %s..%s", tt.period, tt.date, got, tt.wantFrom, tt.wantTo)
+		}
+	}
+}
+
+func TestRangeDays(t *testing.T) {
+	now := mustDate("2024-03-10")
+	tests := []struct {
+		from, to string
+		want     float64
+	}{
+		{"2024-03-01", "2024-03-31", 10},
+		{"2024-02-01", "2024-02-29", 29},
+		{"2024-03-10", "2024-03-10", 1},
+	}
+	for _, tt := range tests {
+		if got := rangeDays(mustDate(tt.from), mustDate(tt.to), now); got != tt.want {
+			t.Errorf("rangeDays(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
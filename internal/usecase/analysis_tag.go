@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"sort"
+
+	"byeboros-backend/internal/adapter/http/model/response"
+)
+
+// GetTagAnalysis groups the income and expense of a date range by tag. A transaction with
+// several tags counts towards each of them; untagged transactions are left out.
+func (u *TransactionUsecase) GetTagAnalysis(spreadsheetID string, fromStr string, toStr string) (*response.TagAnalysisResponse, error) {
+	from, to, err := ParseDateRange(fromStr, toStr)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := loadRecordsInRange(u.sheetRepo, spreadsheetID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	metaIdx, err := loadRecordMeta(u.sheetRepo, spreadsheetID, records)
+	if err != nil {
+		return nil, err
+	}
+
+	type tagTotals struct {
+		item       response.TagAnalysisItem
+		categories map[string]*response.TagAnalysisCategory
+	}
+	byTag := make(map[string]*tagTotals)
+
+	for _, rec := range records {
+		meta := metaIdx.get(rec)
+		for _, tag := range meta.Tags {
+			totals, ok := byTag[tag]
+			if !ok {
+				totals = &tagTotals{
+					item:       response.TagAnalysisItem{Tag: tag},
+					categories: make(map[string]*response.TagAnalysisCategory),
+				}
+				byTag[tag] = totals
+			}
+
+			if rec.Type == "income" {
+				totals.item.TotalIncome += rec.Amount
+			} else {
+				totals.item.TotalExpense += rec.Amount
+			}
+			totals.item.Count++
+
+			key := rec.Type + "|" + rec.Category
+			cat, ok := totals.categories[key]
+			if !ok {
+				cat = &response.TagAnalysisCategory{Type: rec.Type, Category: rec.Category}
+				totals.categories[key] = cat
+			}
+			cat.Amount += rec.Amount
+			cat.Count++
+		}
+	}
+
+	res := &response.TagAnalysisResponse{
+		From: from.Format("2006-01-02"),
+		To:   to.Format("2006-01-02"),
+		Tags: make([]response.TagAnalysisItem, 0, len(byTag)),
+	}
+	for _, totals := range byTag {
+		item := totals.item
+		item.TotalExpenseDisplay = formatAmount(item.TotalExpense, false, u.baseCurrency)
+		item.TotalIncomeDisplay = formatAmount(item.TotalIncome, true, u.baseCurrency)
+		item.Categories = make([]response.TagAnalysisCategory, 0, len(totals.categories))
+		for _, cat := range totals.categories {
+			cat.AmountDisplay = formatAmount(cat.Amount, cat.Type == "income", u.baseCurrency)
+			item.Categories = append(item.Categories, *cat)
+		}
+		sort.SliceStable(item.Categories, func(i, j int) bool {
+			return item.Categories[i].Amount > item.Categories[j].Amount
+		})
+		res.Tags = append(res.Tags, item)
+	}
+
+	sort.SliceStable(res.Tags, func(i, j int) bool {
+		if res.Tags[i].TotalExpense == res.Tags[j].TotalExpense {
+			return res.Tags[i].Tag < res.Tags[j].Tag
+		}
+		return res.Tags[i].TotalExpense > res.Tags[j].TotalExpense
+	})
+	return res, nil
+}
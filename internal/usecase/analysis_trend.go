@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"byeboros-backend/internal/adapter/http/model/response"
+)
+
+// maxTrendPoints caps the series length so a daily trend over many years stays chartable
+const maxTrendPoints = 1000
+
+// trendBucketStart returns the first day of the day, week (starting Monday) or month holding t
+func trendBucketStart(t time.Time, interval string) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch interval {
+	case "week":
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case "month":
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	return day
+}
+
+// trendBucketNext returns the start of the bucket following the one starting at start
+func trendBucketNext(start time.Time, interval string) time.Time {
+	switch interval {
+	case "week":
+		return start.AddDate(0, 0, 7)
+	case "month":
+		return start.AddDate(0, 1, 0)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+// trendBucketLabel formats a bucket for chart axes
+func trendBucketLabel(start time.Time, interval string) string {
+	switch interval {
+	case "week":
+		return start.Format("Jan 2")
+	case "month":
+		return getIndonesianMonthName(int(start.Month())) + " " + start.Format("2006")
+	}
+	return start.Format("Jan 2")
+}
+
+// GetTrendAnalysis returns expense and income totals per day, week or month over a range.
+// Every bucket of the range is present, with zero totals when nothing happened, and the
+// first and last buckets are clipped to the range. With byCategory each category also gets
+// a series aligned with the points.
+func (u *TransactionUsecase) GetTrendAnalysis(spreadsheetID string, fromStr string, toStr string, interval string, byCategory bool) (*response.TrendAnalysisResponse, error) {
+	from, to, err := ParseDateRange(fromStr, toStr)
+	if err != nil {
+		return nil, err
+	}
+	if interval == "" {
+		interval = "day"
+	}
+
+	var starts []time.Time
+	for start := trendBucketStart(from, interval); !start.After(to); start = trendBucketNext(start, interval) {
+		starts = append(starts, start)
+		if len(starts) > maxTrendPoints {
+			return nil, fmt.Errorf("range is too long for a %s trend, use a larger interval", interval)
+		}
+	}
+
+	records, err := loadRecordsInRange(u.sheetRepo, spreadsheetID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &response.TrendAnalysisResponse{
+		From:     from.Format("2006-01-02"),
+		To:       to.Format("2006-01-02"),
+		Interval: interval,
+		Points:   make([]response.TrendPoint, len(starts)),
+	}
+
+	index := make(map[time.Time]int, len(starts))
+	for i, start := range starts {
+		index[start] = i
+		end := trendBucketNext(start, interval).AddDate(0, 0, -1)
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		res.Points[i] = response.TrendPoint{
+			Start: start.Format("2006-01-02"),
+			End:   end.Format("2006-01-02"),
+			Label: trendBucketLabel(starts[i], interval),
+		}
+	}
+
+	series := make(map[string]*response.TrendCategorySeries)
+	for _, rec := range records {
+		i, ok := index[trendBucketStart(rec.Time, interval)]
+		if !ok {
+			continue
+		}
+
+		point := &res.Points[i]
+		if rec.Type == "income" {
+			point.Income += rec.Amount
+			res.TotalIncome += rec.Amount
+		} else {
+			point.Expense += rec.Amount
+			res.TotalExpense += rec.Amount
+		}
+
+		if !byCategory {
+			continue
+		}
+		key := rec.Type + "|" + rec.Category
+		s, ok := series[key]
+		if !ok {
+			s = &response.TrendCategorySeries{
+				Type:     rec.Type,
+				Category: rec.Category,
+				Values:   make([]float64, len(starts)),
+			}
+			series[key] = s
+		}
+		s.Values[i] += rec.Amount
+		s.Total += rec.Amount
+	}
+
+	for i := range res.Points {
+		res.Points[i].ExpenseDisplay = formatAmount(res.Points[i].Expense, false, u.baseCurrency)
+		res.Points[i].IncomeDisplay = formatAmount(res.Points[i].Income, true, u.baseCurrency)
+	}
+	res.TotalExpenseDisplay = formatAmount(res.TotalExpense, false, u.baseCurrency)
+	res.TotalIncomeDisplay = formatAmount(res.TotalIncome, true, u.baseCurrency)
+
+	if byCategory {
+		res.Categories = make([]response.TrendCategorySeries, 0, len(series))
+		for _, s := range series {
+			res.Categories = append(res.Categories, *s)
+		}
+		sort.SliceStable(res.Categories, func(i, j int) bool {
+			if res.Categories[i].Type != res.Categories[j].Type {
+				return res.Categories[i].Type < res.Categories[j].Type
+			}
+			if res.Categories[i].Total == res.Categories[j].Total {
+				return res.Categories[i].Category < res.Categories[j].Category
+			}
+			return res.Categories[i].Total > res.Categories[j].Total
+		})
+	}
+	return res, nil
+}
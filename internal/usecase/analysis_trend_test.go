@@ -0,0 +1,28 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTrendBucketStart(t *testing.T) {
+	tests := []struct {
+		in       time.Time
+		interval string
+		want     string
+	}{
+		{time.Date(2024, 3, 13, 18, 30, 0, 0, time.UTC), "day", "2024-03-13"},
+		{time.Date(2024, 3, 13, 18, 30, 0, 0, time.UTC), "week", "2024-03-11"}, // Wednesday
+		{time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC), "week", "2024-03-11"},   // Monday
+		{time.Date(2024, 3, 17, 23, 59, 0, 0, time.UTC), "week", "2024-03-11"}, // Sunday
+		{time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), "week", "2024-01-01"},
+		{time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), "week", "2022-12-26"}, // Sunday across new year
+		{time.Date(2024, 3, 13, 18, 30, 0, 0, time.UTC), "month", "2024-03-01"},
+		{time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), "month", "2024-02-01"},
+	}
+	for _, tt := range tests {
+		if got := trendBucketStart(tt.in, tt.interval).Format("2006-01-02"); got != tt.want {
+			t.Errorf("trendBucketStart(%s, %s) = %s, want %s", tt.in.Format(time.RFC3339), tt.interval, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"byeboros-backend/internal/adapter/http/model/response"
+)
+
+// yearlyTopLimit caps the top categories, merchants and largest expenses of a yearly summary
+const yearlyTopLimit = 10
+
+// GetYearlyAnalysis summarizes a full year: per-month totals and budget adherence, the top
+// categories and merchants, the largest expenses and the savings rate. The twelve month tabs
+// are read from the year's spreadsheet and only rows dated within the year are counted.
+// A zero year means the year of the requested spreadsheet.
+func (u *TransactionUsecase) GetYearlyAnalysis(spreadsheetID string, year int) (*response.YearlyAnalysisResponse, error) {
+	if year == 0 {
+		var err error
+		if year, err = spreadsheetYear(u.sheetRepo, spreadsheetID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Without a spreadsheet every month would be skipped and the year would read as all zeros
+	years, err := loadYearSpreadsheets(u.sheetRepo, spreadsheetID)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := years[year]; !ok {
+		return nil, fmt.Errorf("%w: no spreadsheet is linked for %d, link it via /api/spreadsheets", ErrYearNotLinked, year)
+	}
+
+	from := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(year, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	tabs, err := monthTabsInRange(u.sheetRepo, spreadsheetID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	values, err := readMonthTabs(u.sheetRepo, tabs, "A2:G", "I2:N", "P2:T")
+	if err != nil {
+		return nil, err
+	}
+	master, err := u.sheetRepo.GetRangeValues(years[year].SpreadsheetID, masterDataSheet+"!F5")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get monthly budget: %w", err)
+	}
+	var monthlyBudget float64
+	if len(master) > 0 && len(master[0]) > 0 {
+		monthlyBudget = parseAmount(master[0][0])
+	}
+	records := recordsInRange(tabs, values, from, to)
+
+	res := &response.YearlyAnalysisResponse{
+		Year:            year,
+		Months:          make([]response.YearlyMonth, 12),
+		TopCategories:   make([]response.YearlyCategory, 0),
+		TopMerchants:    make([]response.YearlyMerchant, 0),
+		LargestExpenses: make([]response.YearlyExpense, 0),
+	}
+	for i := range res.Months {
+		res.Months[i] = response.YearlyMonth{Month: i + 1, Name: getIndonesianMonthName(i + 1)}
+	}
+
+	// Every month's budget is the year's monthly_budget (Master Data F5), or else the sum of
+	// the sub-category budgets of its tab
+	for i, tab := range tabs {
+		m := &res.Months[tab.Month-1]
+		m.HasTab = true
+		m.Budget = monthlyBudget
+		if m.Budget == 0 {
+			for _, row := range values[i][2] {
+				catName := cellString(row, 0)
+				if catName == "" || len(row) < 3 || strings.EqualFold(catName, "Nama Kategori") || strings.EqualFold(catName, "Category") {
+					continue
+				}
+				m.Budget += parseAmount(row[2])
+			}
+		}
+	}
+
+	categories := make(map[string]*response.YearlyCategory)
+	merchants := make(map[string]*response.YearlyMerchant)
+	var expenses []transactionRecord
+	for _, rec := range records {
+		m := &res.Months[rec.Time.Month()-1]
+		if rec.Type == "income" {
+			m.TotalIncome += rec.Amount
+			res.TotalIncome += rec.Amount
+			continue
+		}
+
+		m.TotalExpense += rec.Amount
+		res.TotalExpense += rec.Amount
+		expenses = append(expenses, rec)
+
+		catKey := strings.ToLower(rec.Category)
+		cat, ok := categories[catKey]
+		if !ok {
+			cat = &response.YearlyCategory{Category: rec.Category}
+			categories[catKey] = cat
+		}
+		cat.Amount += rec.Amount
+		cat.Count++
+
+		merchantKey := strings.ToLower(strings.TrimSpace(rec.Description))
+		if merchantKey == "" {
+			continue
+		}
+		merchant, ok := merchants[merchantKey]
+		if !ok {
+			merchant = &response.YearlyMerchant{Description: rec.Description}
+			merchants[merchantKey] = merchant
+		}
+		merchant.Amount += rec.Amount
+		merchant.Count++
+	}
+
+	now := time.Now()
+	for i := range res.Months {
+		m := &res.Months[i]
+		m.Net = m.TotalIncome - m.TotalExpense
+		if m.TotalIncome > 0 {
+			m.SavingsRate = math.Round(m.Net/m.TotalIncome*1000) / 10
+		}
+		m.TotalExpenseDisplay = formatBalance(m.TotalExpense, u.baseCurrency)
+		m.TotalIncomeDisplay = formatBalance(m.TotalIncome, u.baseCurrency)
+		m.NetDisplay = formatBalance(m.Net, u.baseCurrency)
+		m.BudgetDisplay = formatBalance(m.Budget, u.baseCurrency)
+
+		if m.Budget <= 0 {
+			m.BudgetStatus = "no_budget"
+			continue
+		}
+		m.PercentUsed = math.Round(m.TotalExpense/m.Budget*1000) / 10
+
+		// Months still running are judged against how far through they are
+		monthStart := time.Date(year, time.Month(m.Month), 1, 0, 0, 0, 0, time.UTC)
+		progress := 1.0
+		if monthStart.After(now) {
+			progress = 0
+		} else if monthStart.Year() == now.Year() && monthStart.Month() == now.Month() {
+			progress = float64(now.Day()) / float64(monthStart.AddDate(0, 1, -1).Day())
+		}
+		m.BudgetStatus = budgetStatus(m.Budget, m.TotalExpense, progress)
+		if m.BudgetStatus != "over" && progress == 1 {
+			res.MonthsWithinBudget++
+		}
+	}
+
+	res.Net = res.TotalIncome - res.TotalExpense
+	if res.TotalIncome > 0 {
+		res.SavingsRate = math.Round(res.Net/res.TotalIncome*1000) / 10
+	}
+	res.TotalExpenseDisplay = formatBalance(res.TotalExpense, u.baseCurrency)
+	res.TotalIncomeDisplay = formatBalance(res.TotalIncome, u.baseCurrency)
+	res.NetDisplay = formatBalance(res.Net, u.baseCurrency)
+
+	for _, cat := range categories {
+		if res.TotalExpense > 0 {
+			cat.Percent = math.Round(cat.Amount/res.TotalExpense*1000) / 10
+		}
+		cat.AmountDisplay = formatBalance(cat.Amount, u.baseCurrency)
+		res.TopCategories = append(res.TopCategories, *cat)
+	}
+	sort.SliceStable(res.TopCategories, func(i, j int) bool {
+		return res.TopCategories[i].Amount > res.TopCategories[j].Amount
+	})
+	if len(res.TopCategories) > yearlyTopLimit {
+		res.TopCategories = res.TopCategories[:yearlyTopLimit]
+	}
+
+	for _, merchant := range merchants {
+		merchant.AmountDisplay = formatBalance(merchant.Amount, u.baseCurrency)
+		res.TopMerchants = append(res.TopMerchants, *merchant)
+	}
+	sort.SliceStable(res.TopMerchants, func(i, j int) bool {
+		return res.TopMerchants[i].Amount > res.TopMerchants[j].Amount
+	})
+	if len(res.TopMerchants) > yearlyTopLimit {
+		res.TopMerchants = res.TopMerchants[:yearlyTopLimit]
+	}
+
+	sort.SliceStable(expenses, func(i, j int) bool {
+		return expenses[i].Amount > expenses[j].Amount
+	})
+	for i, rec := range expenses {
+		if i == yearlyTopLimit {
+			break
+		}
+		res.LargestExpenses = append(res.LargestExpenses, response.YearlyExpense{
+			ID:            rec.ID,
+			SheetName:     rec.SheetName,
+			Description:   rec.Description,
+			Category:      rec.Category,
+			Amount:        rec.Amount,
+			AmountDisplay: formatBalance(rec.Amount, u.baseCurrency),
+			Date:          rec.Time.Format("2006-01-02"),
+		})
+	}
+	return res, nil
+}
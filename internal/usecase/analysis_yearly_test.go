@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestGetYearlyAnalysis(t *testing.T) {
+	budgets := [][]interface{}{
+		{"Nama Kategori", "Sub kategori", "Budget", "Alokasi", "Sisa"},
+		{"Kebutuhan", "Makan", 2000000},
+		{"Kebutuhan", "Transportasi", 600000},
+	}
+	values := func(monthlyBudget interface{}) map[string][][]interface{} {
+		return map[string][][]interface{}{
+			yearSpreadsheetSheet + "!A2:B": {{2024, "sheet-id"}},
+			masterDataSheet + "!F5":        {{monthlyBudget}},
+			"Januari!A2:G": {
+				{"Belanja bulanan", "Makan", "Tinggi", 2000000, "", "1/5/2024 10:00:00"},
+				{"Bensin", "Transportasi", "Sedang", 500000, "", "1/6/2024 8:00:00"},
+			},
+			"Januari!I2:N": {{"Gaji", "Gaji", 8000000, "", "1/1/2024 9:00:00"}},
+			"Januari!P2:T": budgets,
+			"Februari!A2:G": {
+				{"belanja bulanan ", "makan", "Tinggi", 3500000, "", "2/5/2024 10:00:00"},
+			},
+			"Februari!I2:N": {{"Gaji", "Gaji", 8000000, "", "2/1/2024 9:00:00"}},
+			"Februari!P2:T": budgets,
+			// A row dated in the previous year is not counted
+			"Maret!A2:G": {{"Sisa tahun lalu", "Makan", "", 999000, "", "12/31/2023 10:00:00"}},
+			"Maret!P2:T": budgets,
+		}
+	}
+
+	tests := []struct {
+		name          string
+		monthlyBudget interface{}
+		wantBudget    float64
+		wantStatuses  []string // January to April
+	}{
+		{"monthly budget from the master data", 3000000, 3000000, []string{"on_track", "over", "on_track", "no_budget"}},
+		{"sub-category budgets without a monthly budget", "", 2600000, []string{"warning", "over", "on_track", "no_budget"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, fake := newFakeSheetRepo(t, []string{masterDataSheet, "Januari", "Februari", "Maret"}, values(tt.monthlyBudget))
+			res, err := NewTransactionUsecase(repo, nil, "IDR").GetYearlyAnalysis("sheet-id", 0)
+			if err != nil {
+				t.Fatalf("GetYearlyAnalysis: %v", err)
+			}
+			if fake.read("Januari!F5") {
+				t.Error("monthly budget was read from the month tab's expense rows")
+			}
+
+			if res.Year != 2024 || res.TotalExpense != 6000000 || res.TotalIncome != 16000000 || res.Net != 10000000 || res.SavingsRate != 62.5 {
+				t.Errorf("year %d totals = %v/%v/%v/%v%%, want 2024 6000000/16000000/10000000/62.5%%",
+					res.Year, res.TotalExpense, res.TotalIncome, res.Net, res.SavingsRate)
+			}
+			for i, want := range tt.wantStatuses {
+				if got := res.Months[i].BudgetStatus; got != want {
+					t.Errorf("%s status = %s, want %s", res.Months[i].Name, got, want)
+				}
+			}
+			if got := res.Months[0].Budget; got != tt.wantBudget {
+				t.Errorf("January budget = %v, want %v", got, tt.wantBudget)
+			}
+			if res.MonthsWithinBudget != 2 {
+				t.Errorf("MonthsWithinBudget = %d, want 2", res.MonthsWithinBudget)
+			}
+
+			// Categories and merchants are grouped case-insensitively
+			if top := res.TopCategories[0]; top.Category != "Makan" || top.Amount != 5500000 || top.Count != 2 || top.Percent != 91.7 {
+				t.Errorf("top category = %+v, want Makan 5500000 in 2 expenses (91.7%%)", top)
+			}
+			if top := res.TopMerchants[0]; top.Description != "Belanja bulanan" || top.Amount != 5500000 || top.Count != 2 {
+				t.Errorf("top merchant = %+v, want Belanja bulanan 5500000 in 2 expenses", top)
+			}
+			if len(res.LargestExpenses) != 3 || res.LargestExpenses[0].Amount != 3500000 || res.LargestExpenses[0].Date != "2024-02-05" {
+				t.Errorf("largest expenses = %+v, want 3 starting with February's 3500000", res.LargestExpenses)
+			}
+		})
+	}
+}
+
+func TestGetYearlyAnalysisUnlinkedYear(t *testing.T) {
+	repo, _ := newFakeSheetRepo(t, []string{"Januari"}, map[string][][]interface{}{
+		yearSpreadsheetSheet + "!A2:B": {{2024, "sheet-id"}},
+	})
+
+	if _, err := NewTransactionUsecase(repo, nil, "IDR").GetYearlyAnalysis("sheet-id", 2023); !errors.Is(err, ErrYearNotLinked) {
+		t.Errorf("err = %v, want ErrYearNotLinked", err)
+	}
+}
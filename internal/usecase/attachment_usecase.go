@@ -0,0 +1,189 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+
+	"byeboros-backend/internal/adapter/http/model/response"
+	"byeboros-backend/internal/adapter/repository"
+	"byeboros-backend/internal/infrastructure/blobstore"
+)
+
+var (
+	// ErrAttachmentTooLarge is returned when an upload exceeds the configured size limit
+	ErrAttachmentTooLarge = errors.New("attachment is too large")
+
+	// ErrAttachmentType is returned when an upload is not an image or PDF
+	ErrAttachmentType = errors.New("attachment type is not allowed")
+
+	// ErrAttachmentNotFound is returned when a transaction has no such attachment
+	ErrAttachmentNotFound = errors.New("attachment not found")
+)
+
+// attachmentTypes maps the allowed content types, as sniffed from the file, to the
+// extension of the stored attachment
+var attachmentTypes = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/webp":      ".webp",
+	"application/pdf": ".pdf",
+}
+
+// AttachmentUsecase stores receipt files for transactions. The files live in a blob store;
+// the transaction's meta row keeps the list of attachment IDs.
+type AttachmentUsecase struct {
+	sheetRepo *repository.SheetRepository
+	store     blobstore.Store
+	maxSize   int64
+}
+
+// NewAttachmentUsecase creates a new AttachmentUsecase accepting files up to maxSize bytes
+func NewAttachmentUsecase(sheetRepo *repository.SheetRepository, store blobstore.Store, maxSize int64) *AttachmentUsecase {
+	return &AttachmentUsecase{sheetRepo: sheetRepo, store: store, maxSize: maxSize}
+}
+
+// MaxSize returns the upload size limit in bytes
+func (u *AttachmentUsecase) MaxSize() int64 {
+	return u.maxSize
+}
+
+// attachmentKey is the blob key of an attachment; IDs are unique so the spreadsheet prefix
+// only keeps the files of different users apart
+func attachmentKey(spreadsheetID, attachmentID string) string {
+	return spreadsheetID + "/" + attachmentID
+}
+
+// attachmentContentType derives the content type of a stored attachment from its extension
+func attachmentContentType(attachmentID string) string {
+	ext := path.Ext(attachmentID)
+	for contentType, e := range attachmentTypes {
+		if e == ext {
+			return contentType
+		}
+	}
+	return "application/octet-stream"
+}
+
+func attachmentItem(transactionID, attachmentID string, size int64) response.AttachmentItem {
+	return response.AttachmentItem{
+		ID:            attachmentID,
+		TransactionID: transactionID,
+		ContentType:   attachmentContentType(attachmentID),
+		Size:          size,
+		DownloadURL:   fmt.Sprintf("/api/transaction/%s/attachments/%s", transactionID, attachmentID),
+	}
+}
+
+// AddAttachment validates and stores a file, then references it from the transaction
+func (u *AttachmentUsecase) AddAttachment(ctx context.Context, spreadsheetID string, sheetName string, transactionID string, r io.Reader) (*response.AttachmentItem, error) {
+	if _, err := loadTransactionRecord(u.sheetRepo, spreadsheetID, sheetName, transactionID); err != nil {
+		return nil, err
+	}
+
+	// Read one byte past the limit to detect oversized files without trusting the header
+	data, err := io.ReadAll(io.LimitReader(r, u.maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read attachment: %w", err)
+	}
+	if int64(len(data)) > u.maxSize {
+		return nil, fmt.Errorf("%w: maximum size is %d MB", ErrAttachmentTooLarge, u.maxSize/(1<<20))
+	}
+
+	contentType := http.DetectContentType(data)
+	ext, ok := attachmentTypes[contentType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s (allowed: JPEG, PNG, WebP, PDF)", ErrAttachmentType, contentType)
+	}
+
+	attachmentID := newID("att") + ext
+	key := attachmentKey(spreadsheetID, attachmentID)
+	if err := u.store.Put(ctx, key, contentType, data); err != nil {
+		return nil, err
+	}
+
+	err = updateTransactionMeta(u.sheetRepo, spreadsheetID, sheetName, []string{transactionID}, func(m *transactionMeta) {
+		m.Attachments = append(m.Attachments, attachmentID)
+	})
+	if err != nil {
+		// Do not leave an unreferenced file behind
+		_ = u.store.Delete(ctx, key)
+		return nil, err
+	}
+
+	item := attachmentItem(transactionID, attachmentID, int64(len(data)))
+	return &item, nil
+}
+
+// GetAttachments lists the attachments of a transaction
+func (u *AttachmentUsecase) GetAttachments(spreadsheetID string, sheetName string, transactionID string) ([]response.AttachmentItem, error) {
+	idx, err := loadTransactionMeta(u.sheetRepo, spreadsheetID)
+	if err != nil {
+		return nil, err
+	}
+
+	meta := idx.get(sheetName, transactionID)
+	items := make([]response.AttachmentItem, 0, len(meta.Attachments))
+	for _, id := range meta.Attachments {
+		items = append(items, attachmentItem(transactionID, id, 0))
+	}
+	return items, nil
+}
+
+// OpenAttachment returns the content of an attachment of a transaction and its content type.
+// The caller closes the reader.
+func (u *AttachmentUsecase) OpenAttachment(ctx context.Context, spreadsheetID string, sheetName string, transactionID string, attachmentID string) (io.ReadCloser, string, error) {
+	if err := u.checkAttachment(spreadsheetID, sheetName, transactionID, attachmentID); err != nil {
+		return nil, "", err
+	}
+
+	rc, err := u.store.Get(ctx, attachmentKey(spreadsheetID, attachmentID))
+	if errors.Is(err, blobstore.ErrNotFound) {
+		return nil, "", fmt.Errorf("%w: %s", ErrAttachmentNotFound, attachmentID)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return rc, attachmentContentType(attachmentID), nil
+}
+
+// DeleteAttachment removes an attachment from a transaction and the blob store
+func (u *AttachmentUsecase) DeleteAttachment(ctx context.Context, spreadsheetID string, sheetName string, transactionID string, attachmentID string) error {
+	if err := u.checkAttachment(spreadsheetID, sheetName, transactionID, attachmentID); err != nil {
+		return err
+	}
+
+	err := updateTransactionMeta(u.sheetRepo, spreadsheetID, sheetName, []string{transactionID}, func(m *transactionMeta) {
+		kept := m.Attachments[:0]
+		for _, id := range m.Attachments {
+			if id != attachmentID {
+				kept = append(kept, id)
+			}
+		}
+		m.Attachments = kept
+	})
+	if err != nil {
+		return err
+	}
+
+	return u.store.Delete(ctx, attachmentKey(spreadsheetID, attachmentID))
+}
+
+// checkAttachment makes sure the attachment is referenced by the transaction, so one
+// transaction's ID cannot be used to reach another's files
+func (u *AttachmentUsecase) checkAttachment(spreadsheetID string, sheetName string, transactionID string, attachmentID string) error {
+	idx, err := loadTransactionMeta(u.sheetRepo, spreadsheetID)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range idx.get(sheetName, transactionID).Attachments {
+		if id == attachmentID {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrAttachmentNotFound, attachmentID)
+}
@@ -0,0 +1,283 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"byeboros-backend/internal/adapter/http/model/response"
+)
+
+const (
+	// recurringLookbackMonths is how many previous months are searched for recurring items
+	recurringLookbackMonths = 3
+
+	// recurringMinMonths is how many of those months an item must appear in to be recurring
+	recurringMinMonths = 2
+)
+
+// recurringItem is an expense that came back in most of the previous months
+type recurringItem struct {
+	Description     string
+	SubCategoryName string
+	ExpectedAmount  float64 // average monthly amount
+	ExpectedDay     int
+	Paid            bool // already recorded in the forecast month
+}
+
+func recurringKey(description, category string) string {
+	return strings.ToLower(strings.TrimSpace(description)) + "|" + strings.ToLower(strings.TrimSpace(category))
+}
+
+// findRecurringItems detects the expenses (same description and sub-category) recorded in at
+// least recurringMinMonths of the recurringLookbackMonths months before monthStart
+func findRecurringItems(history []transactionRecord, monthStart time.Time) map[string]*recurringItem {
+	lookbackStart := monthStart.AddDate(0, -recurringLookbackMonths, 0)
+
+	type occurrence struct {
+		desc, category string
+		months         map[string]float64
+		daySum, count  int
+	}
+	occurrences := make(map[string]*occurrence)
+	for _, rec := range history {
+		if rec.Type != "expense" || rec.Time.Before(lookbackStart) || !rec.Time.Before(monthStart) {
+			continue
+		}
+		key := recurringKey(rec.Description, rec.Category)
+		o, ok := occurrences[key]
+		if !ok {
+			o = &occurrence{desc: rec.Description, category: rec.Category, months: make(map[string]float64)}
+			occurrences[key] = o
+		}
+		o.months[rec.Time.Format("2006-01")] += rec.Amount
+		o.daySum += rec.Time.Day()
+		o.count++
+	}
+
+	items := make(map[string]*recurringItem)
+	for key, o := range occurrences {
+		if len(o.months) < recurringMinMonths {
+			continue
+		}
+		var total float64
+		for _, amount := range o.months {
+			total += amount
+		}
+		items[key] = &recurringItem{
+			Description:     o.desc,
+			SubCategoryName: o.category,
+			ExpectedAmount:  math.Round(total / float64(len(o.months))),
+			ExpectedDay:     int(math.Round(float64(o.daySum) / float64(o.count))),
+		}
+	}
+	return items
+}
+
+// GetBudgetForecast projects the end-of-month spending of a month tab per sub-category.
+// Recurring items are forecast at their usual amount until they are recorded; the rest of
+// the spending is extrapolated from the pace so far and, when the same month of last year
+// is available, from how much of that month's spending had happened by the same day.
+func (u *BudgetUsecase) GetBudgetForecast(spreadsheetID string, sheetName string) (*response.BudgetForecastResponse, error) {
+	month := sheetMonth(sheetName)
+	if month == 0 {
+		return nil, fmt.Errorf("%s is not a month tab", sheetName)
+	}
+	year, err := spreadsheetYear(u.sheetRepo, spreadsheetID)
+	if err != nil {
+		return nil, err
+	}
+
+	monthStart := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	monthEnd := monthStart.AddDate(0, 1, -1)
+	daysInMonth := monthEnd.Day()
+
+	now := time.Now()
+	asOf := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if asOf.After(monthEnd) {
+		asOf = monthEnd
+	}
+	daysElapsed := 0
+	if !asOf.Before(monthStart) {
+		daysElapsed = asOf.Day()
+	}
+
+	// Last year's same month feeds the seasonal pattern, the previous months the recurring items
+	history, err := loadRecordsInRange(u.sheetRepo, spreadsheetID, monthStart.AddDate(-1, 0, 0), monthEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	valueRanges, err := u.sheetRepo.BatchGetValues(spreadsheetID, []string{sheetName + "!P2:T", masterDataSheet + "!F5"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch budget data: %w", err)
+	}
+	carries, err := budgetCarryOver(u.sheetRepo, spreadsheetID, sheetName)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &response.BudgetForecastResponse{
+		SheetName:      sheetName,
+		AsOf:           asOf.Format("2006-01-02"),
+		DaysElapsed:    daysElapsed,
+		DaysInMonth:    daysInMonth,
+		Categories:     make([]response.BudgetForecastItem, 0),
+		RecurringItems: make([]response.BudgetForecastRecurring, 0),
+	}
+	if len(valueRanges) > 1 && valueRanges[1] != nil && len(valueRanges[1].Values) > 0 && len(valueRanges[1].Values[0]) > 0 {
+		res.MonthlyBudget = parseAmount(valueRanges[1].Values[0][0])
+	}
+
+	// Budget rows give the sub-categories and their effective budgets
+	items := make(map[string]*response.BudgetForecastItem)
+	var order []string
+	if len(valueRanges) > 0 && valueRanges[0] != nil {
+		for _, row := range valueRanges[0].Values {
+			catName := cellString(row, 0)
+			subCatName := cellString(row, 1)
+			if catName == "" || len(row) < 3 || strings.EqualFold(catName, "Nama Kategori") || strings.EqualFold(catName, "Category") {
+				continue
+			}
+			key := strings.ToLower(subCatName)
+			if _, ok := items[key]; ok {
+				continue
+			}
+			items[key] = &response.BudgetForecastItem{
+				CategoryName:    catName,
+				SubCategoryName: subCatName,
+				Budget:          parseAmount(row[2]) + carries[budgetKey(catName, subCatName)].CarryOver,
+			}
+			order = append(order, key)
+		}
+	}
+	item := func(category string) *response.BudgetForecastItem {
+		key := strings.ToLower(strings.TrimSpace(category))
+		if it, ok := items[key]; ok {
+			return it
+		}
+		it := &response.BudgetForecastItem{CategoryName: uncategorizedExpense, SubCategoryName: category}
+		items[key] = it
+		order = append(order, key)
+		return it
+	}
+
+	recurring := findRecurringItems(history, monthStart)
+
+	// Split this month's spending into recurring and variable, and collect last year's
+	// same-month spending in total and up to the same day
+	variable := make(map[string]float64)
+	lastYear := make(map[string]float64)
+	lastYearToDate := make(map[string]float64)
+	lastYearStart := monthStart.AddDate(-1, 0, 0)
+	for _, rec := range history {
+		if rec.Type != "expense" {
+			continue
+		}
+		key := strings.ToLower(strings.TrimSpace(rec.Category))
+
+		switch {
+		case !rec.Time.Before(monthStart):
+			it := item(rec.Category)
+			it.Spent += rec.Amount
+			if r, ok := recurring[recurringKey(rec.Description, rec.Category)]; ok {
+				r.Paid = true
+			} else {
+				variable[key] += rec.Amount
+			}
+		case rec.Time.Year() == lastYearStart.Year() && rec.Time.Month() == lastYearStart.Month():
+			item(rec.Category)
+			lastYear[key] += rec.Amount
+			if rec.Time.Day() <= daysElapsed {
+				lastYearToDate[key] += rec.Amount
+			}
+		}
+	}
+
+	pending := make(map[string]float64)
+	for _, r := range recurring {
+		item(r.SubCategoryName)
+		if !r.Paid && daysElapsed < daysInMonth {
+			pending[strings.ToLower(strings.TrimSpace(r.SubCategoryName))] += r.ExpectedAmount
+		}
+		res.RecurringItems = append(res.RecurringItems, response.BudgetForecastRecurring{
+			Description:           r.Description,
+			SubCategoryName:       r.SubCategoryName,
+			ExpectedAmount:        r.ExpectedAmount,
+			ExpectedAmountDisplay: formatBalance(r.ExpectedAmount, u.baseCurrency),
+			ExpectedDay:           r.ExpectedDay,
+			Paid:                  r.Paid,
+		})
+	}
+	sort.SliceStable(res.RecurringItems, func(i, j int) bool {
+		return res.RecurringItems[i].ExpectedDay < res.RecurringItems[j].ExpectedDay
+	})
+
+	for _, key := range order {
+		it := items[key]
+		it.RecurringPending = pending[key]
+		it.LastYearSameMonth = lastYear[key]
+
+		var remainingVariable float64
+		remainingVariable, it.Method = projectRemainingVariable(variable[key], lastYear[key], lastYearToDate[key],
+			math.Max(it.Budget-it.RecurringPending, 0), daysElapsed, daysInMonth)
+
+		it.Projected = math.Round(it.Spent + remainingVariable + it.RecurringPending)
+		it.ProjectedRemaining = it.Budget - it.Projected
+		it.Status = "on_track"
+		if it.Projected > it.Budget {
+			it.Status = "over"
+		}
+		it.BudgetDisplay = formatBalance(it.Budget, u.baseCurrency)
+		it.SpentDisplay = formatBalance(it.Spent, u.baseCurrency)
+		it.ProjectedDisplay = formatBalance(it.Projected, u.baseCurrency)
+		it.ProjectedRemainingDisplay = formatBalance(it.ProjectedRemaining, u.baseCurrency)
+
+		res.TotalSpent += it.Spent
+		res.ProjectedTotal += it.Projected
+		if it.Spent != 0 || it.Projected != 0 || it.Budget != 0 {
+			res.Categories = append(res.Categories, *it)
+		}
+	}
+
+	res.ProjectedRemaining = res.MonthlyBudget - res.ProjectedTotal
+	res.Status = "on_track"
+	if res.MonthlyBudget > 0 && res.ProjectedTotal > res.MonthlyBudget {
+		res.Status = "over"
+	}
+	res.TotalSpentDisplay = formatBalance(res.TotalSpent, u.baseCurrency)
+	res.ProjectedTotalDisplay = formatBalance(res.ProjectedTotal, u.baseCurrency)
+	res.ProjectedRemainingDisplay = formatBalance(res.ProjectedRemaining, u.baseCurrency)
+
+	sort.SliceStable(res.Categories, func(i, j int) bool {
+		return res.Categories[i].ProjectedRemaining < res.Categories[j].ProjectedRemaining
+	})
+	return res, nil
+}
+
+// projectRemainingVariable estimates the variable spending still to come this month from the
+// pace so far and, when known, last year's share of the month spent by the same day. Before
+// the month starts it expects last year's amount, or else the budget left after recurring items.
+// It returns the amount and the method used.
+func projectRemainingVariable(variable, lastYear, lastYearToDate, budgetLeft float64, daysElapsed, daysInMonth int) (float64, string) {
+	switch {
+	case daysElapsed == 0:
+		if lastYear > 0 {
+			return lastYear, "last_year"
+		}
+		return budgetLeft, "budget"
+	case daysElapsed >= daysInMonth:
+		return 0, "actual"
+	}
+
+	projected := variable / float64(daysElapsed) * float64(daysInMonth)
+	method := "pace"
+	if lastYear > 0 && lastYearToDate > 0 {
+		pattern := variable / (lastYearToDate / lastYear)
+		projected = (projected + pattern) / 2
+		method = "pace_and_last_year"
+	}
+	return math.Max(projected-variable, 0), method
+}
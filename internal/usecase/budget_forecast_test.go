@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFindRecurringItems(t *testing.T) {
+	expense := func(desc, category string, amount float64, at string) transactionRecord {
+		return transactionRecord{Type: "expense", Description: desc, Category: category, Amount: amount, Time: mustDate(at)}
+	}
+	history := []transactionRecord{
+		// Internet in two of the three previous months, once with different casing
+		expense("Internet", "Tagihan", 350000, "2025-01-05"),
+		expense(" internet ", "tagihan", 370000, "2025-02-07"),
+		// Rent every month, paid in two parts in March
+		expense("Kos", "Tempat Tinggal", 1500000, "2025-01-01"),
+		expense("Kos", "Tempat Tinggal", 1500000, "2025-02-01"),
+		expense("Kos", "Tempat Tinggal", 750000, "2025-03-01"),
+		expense("Kos", "Tempat Tinggal", 750000, "2025-03-02"),
+		// Only once in the lookback window
+		expense("Servis motor", "Transportasi", 200000, "2025-02-20"),
+		// Before the lookback window and in the forecast month do not count
+		expense("Servis motor", "Transportasi", 200000, "2024-12-20"),
+		expense("Servis motor", "Transportasi", 200000, "2025-04-02"),
+		// Income never recurs as an expense
+		{Type: "income", Description: "Gaji", Category: "Gaji", Amount: 8000000, Time: mustDate("2025-01-25")},
+		{Type: "income", Description: "Gaji", Category: "Gaji", Amount: 8000000, Time: mustDate("2025-02-25")},
+	}
+
+	items := findRecurringItems(history, mustDate("2025-04-01"))
+	if len(items) != 2 {
+		t.Fatalf("got %d recurring items, want 2: %v", len(items), items)
+	}
+
+	tests := []struct {
+		desc, category string
+		wantAmount     float64
+		wantDay        int
+	}{
+		{"Internet", "Tagihan", 360000, 6}, // averaged per month it was paid in
+		{"Kos", "Tempat Tinggal", 1500000, 1},
+	}
+	for _, tt := range tests {
+		item, ok := items[recurringKey(tt.desc, tt.category)]
+		if !ok {
+			t.Errorf("%s is not recurring", tt.desc)
+			continue
+		}
+		if item.ExpectedAmount != tt.wantAmount || item.ExpectedDay != tt.wantDay {
+			t.Errorf("%s = %v on day %d, want %v on day %d", tt.desc, item.ExpectedAmount, item.ExpectedDay, tt.wantAmount, tt.wantDay)
+		}
+	}
+}
+
+func TestProjectRemainingVariable(t *testing.T) {
+	tests := []struct {
+		name           string
+		variable       float64
+		lastYear       float64
+		lastYearToDate float64
+		budgetLeft     float64
+		daysElapsed    int
+		wantAmount     float64
+		wantMethod     string
+	}{
+		{"pace", 300000, 0, 0, 0, 10, 600000, "pace"},
+		// Pace says 900000 in total; last year 25% was spent by day 10, so 1200000
+		{"pace and last year", 300000, 800000, 200000, 0, 10, 750000, "pace_and_last_year"},
+		{"last year without spending to date", 300000, 800000, 0, 0, 10, 600000, "pace"},
+		{"ahead of last year's pattern never goes negative", 300000, 100000, 100000, 0, 10, 300000, "pace_and_last_year"},
+		{"month not started expects last year", 0, 800000, 0, 500000, 0, 800000, "last_year"},
+		{"month not started falls back to the budget", 0, 0, 0, 500000, 0, 500000, "budget"},
+		{"month over", 900000, 800000, 800000, 0, 30, 0, "actual"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, method := projectRemainingVariable(tt.variable, tt.lastYear, tt.lastYearToDate, tt.budgetLeft, tt.daysElapsed, 30)
+			if math.Round(amount) != tt.wantAmount || method != tt.wantMethod {
+				t.Errorf("got %v (%s), want %v (%s)", amount, method, tt.wantAmount, tt.wantMethod)
+			}
+		})
+	}
+}
+
+func TestGetBudgetForecastReadsMonthlyBudgetFromMasterData(t *testing.T) {
+	repo, fake := newFakeSheetRepo(t, []string{masterDataSheet, "Januari", "Februari", "Maret"}, map[string][][]interface{}{
+		yearSpreadsheetSheet + "!A2:B": {{2024, "sheet-id"}},
+		masterDataSheet + "!F5":        {{3000000}},
+		"Maret!A2:G": {
+			{"Makan siang", "Makan", "Kebutuhan", 50000, "", "3/4/2024 12:00:00"},
+		},
+		"Maret!P2:T": {
+			{"Nama Kategori", "Sub kategori", "Budget", "Alokasi", "Sisa"},
+			{"Kebutuhan", "Makan", 1500000, 50000, 1450000},
+		},
+	})
+
+	res, err := NewBudgetUsecase(repo, "IDR").GetBudgetForecast("sheet-id", "Maret")
+	if err != nil {
+		t.Fatalf("GetBudgetForecast: %v", err)
+	}
+	if fake.read("Maret!F5") {
+		t.Error("monthly budget was read from the month tab's expense rows")
+	}
+	if res.MonthlyBudget != 3000000 {
+		t.Errorf("MonthlyBudget = %v, want 3000000", res.MonthlyBudget)
+	}
+	// March 2024 is over, so the forecast is what was spent
+	if res.ProjectedTotal != 50000 || res.ProjectedRemaining != 2950000 || res.Status != "on_track" {
+		t.Errorf("ProjectedTotal = %v, ProjectedRemaining = %v, Status = %q, want 50000, 2950000, on_track",
+			res.ProjectedTotal, res.ProjectedRemaining, res.Status)
+	}
+	if res.DaysElapsed != 31 || res.AsOf != "2024-03-31" {
+		t.Errorf("DaysElapsed = %d, AsOf = %s, want 31, 2024-03-31", res.DaysElapsed, res.AsOf)
+	}
+}
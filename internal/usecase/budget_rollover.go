@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"byeboros-backend/internal/adapter/http/model/request"
+	"byeboros-backend/internal/adapter/http/model/response"
+	"byeboros-backend/internal/adapter/repository"
+)
+
+// budgetRolloverSheet stores the rollover mode of each sub-category
+const budgetRolloverSheet = "Budget Rollover"
+
+var budgetRolloverHeaders = []interface{}{"Category", "Sub Category", "Mode"}
+
+// ErrNotMonthTab is returned when a month tab is required but the sheet name is not a month
+var ErrNotMonthTab = errors.New("not a month tab")
+
+const (
+	rolloverNone              = "none"
+	rolloverSurplus           = "surplus"
+	rolloverSurplusAndDeficit = "surplus_and_deficit"
+)
+
+// budgetRollover is one row of the "Budget Rollover" sheet
+type budgetRollover struct {
+	Row             int
+	CategoryName    string
+	SubCategoryName string
+	Mode            string
+}
+
+// budgetKey identifies a sub-category case-insensitively
+func budgetKey(categoryName, subCategoryName string) string {
+	return strings.ToLower(strings.TrimSpace(categoryName)) + "|" + strings.ToLower(strings.TrimSpace(subCategoryName))
+}
+
+// budgetCarry is the rollover mode of a sub-category and the amount carried into a month
+type budgetCarry struct {
+	Mode      string
+	CarryOver float64
+}
+
+// carryFrom returns what a month's remainder contributes to the next month
+func (c budgetCarry) carryFrom(remaining float64) float64 {
+	switch c.Mode {
+	case rolloverSurplus:
+		return math.Max(remaining, 0)
+	case rolloverSurplusAndDeficit:
+		return remaining
+	}
+	return 0
+}
+
+func loadBudgetRollovers(sheetRepo *repository.SheetRepository, spreadsheetID string) (map[string]*budgetRollover, error) {
+	rows, err := sheetRepo.GetOptionalRangeValuesUnformatted(spreadsheetID, budgetRolloverSheet+"!A2:C")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get budget rollover settings: %w", err)
+	}
+
+	rollovers := make(map[string]*budgetRollover)
+	for i, row := range rows {
+		catName := cellString(row, 0)
+		if catName == "" {
+			continue
+		}
+		r := &budgetRollover{
+			Row:             i + 2,
+			CategoryName:    catName,
+			SubCategoryName: cellString(row, 1),
+			Mode:            cellString(row, 2),
+		}
+		rollovers[budgetKey(r.CategoryName, r.SubCategoryName)] = r
+	}
+	return rollovers, nil
+}
+
+// budgetCarryOver computes, per sub-category, the amount carried into the given month tab.
+// The remainders are chained from January of the earliest linked year, so December's
+// remainder reaches January of the next year's spreadsheet. Each month's remainder is
+// measured against its own effective budget (budget + carry).
+func budgetCarryOver(sheetRepo *repository.SheetRepository, spreadsheetID string, sheetName string) (map[string]budgetCarry, error) {
+	rollovers, err := loadBudgetRollovers(sheetRepo, spreadsheetID)
+	if err != nil {
+		return nil, err
+	}
+
+	carries := make(map[string]budgetCarry)
+	for key, r := range rollovers {
+		if r.Mode != rolloverSurplus && r.Mode != rolloverSurplusAndDeficit {
+			continue
+		}
+		carries[key] = budgetCarry{Mode: r.Mode}
+	}
+
+	month := sheetMonth(sheetName)
+	if len(carries) == 0 || month == 0 {
+		return carries, nil
+	}
+
+	years, err := loadYearSpreadsheets(sheetRepo, spreadsheetID)
+	if err != nil {
+		return nil, err
+	}
+	year := yearOf(years, spreadsheetID)
+	first := year
+	for y := range years {
+		first = min(first, y)
+	}
+
+	from := time.Date(first, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(year, time.Month(month), 0, 0, 0, 0, 0, time.UTC) // last day of the previous month
+	tabs, err := monthTabsInRange(sheetRepo, spreadsheetID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if len(tabs) == 0 {
+		return carries, nil
+	}
+
+	values, err := readMonthTabs(sheetRepo, tabs, "P2:T")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch previous budgets: %w", err)
+	}
+
+	blocks := make([][][]interface{}, len(tabs))
+	for i := range tabs {
+		blocks[i] = values[i][0]
+	}
+	carryThrough(carries, blocks)
+	return carries, nil
+}
+
+// carryThrough chains the remainders of the P2:T budget blocks of consecutive months, oldest
+// first, into the carries of the configured sub-categories
+func carryThrough(carries map[string]budgetCarry, blocks [][][]interface{}) {
+	for _, rows := range blocks {
+		for _, row := range rows {
+			if len(row) < 3 {
+				continue
+			}
+			key := budgetKey(fmt.Sprintf("%v", row[0]), fmt.Sprintf("%v", row[1]))
+			carry, ok := carries[key]
+			if !ok {
+				continue
+			}
+			var spent float64
+			if len(row) > 3 {
+				spent = parseAmount(row[3])
+			}
+			carry.CarryOver = carry.carryFrom(parseAmount(row[2]) + carry.CarryOver - spent)
+			carries[key] = carry
+		}
+	}
+}
+
+// GetBudgetRollovers returns the rollover mode of every configured sub-category
+func (u *BudgetUsecase) GetBudgetRollovers(spreadsheetID string) ([]response.BudgetRolloverItem, error) {
+	rollovers, err := loadBudgetRollovers(u.sheetRepo, spreadsheetID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]response.BudgetRolloverItem, 0, len(rollovers))
+	for _, r := range rollovers {
+		items = append(items, response.BudgetRolloverItem{
+			CategoryName:    r.CategoryName,
+			SubCategoryName: r.SubCategoryName,
+			Mode:            r.Mode,
+		})
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].CategoryName == items[j].CategoryName {
+			return items[i].SubCategoryName < items[j].SubCategoryName
+		}
+		return items[i].CategoryName < items[j].CategoryName
+	})
+	return items, nil
+}
+
+// SaveBudgetRollover sets the rollover mode of a sub-category
+func (u *BudgetUsecase) SaveBudgetRollover(spreadsheetID string, req request.BudgetRolloverRequest) error {
+	rollovers, err := loadBudgetRollovers(u.sheetRepo, spreadsheetID)
+	if err != nil {
+		return err
+	}
+
+	values := []interface{}{req.CategoryName, req.SubCategoryName, req.Mode}
+	if existing, ok := rollovers[budgetKey(req.CategoryName, req.SubCategoryName)]; ok {
+		if err := u.sheetRepo.UpdateRow(spreadsheetID, budgetRolloverSheet, existing.Row, values); err != nil {
+			return fmt.Errorf("failed to update budget rollover: %w", err)
+		}
+		return nil
+	}
+
+	if err := u.sheetRepo.EnsureSheet(spreadsheetID, budgetRolloverSheet, budgetRolloverHeaders); err != nil {
+		return err
+	}
+	if err := u.sheetRepo.AppendRow(spreadsheetID, budgetRolloverSheet+"!A:C", values); err != nil {
+		return fmt.Errorf("failed to save budget rollover: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCarryThrough(t *testing.T) {
+	makan := budgetKey("Kebutuhan", "Makan")
+	jajan := budgetKey("Keinginan", "Jajan")
+	blocks := [][][]interface{}{
+		{ // January: Makan leaves 200000, Jajan overspends by 50000
+			{"Nama Kategori", "Sub kategori", "Budget", "Alokasi", "Sisa"},
+			{"Kebutuhan", "Makan", 1000000, 800000},
+			{"Keinginan", "Jajan", 300000, 350000},
+		},
+		{ // February: Makan spends 1100000 of 1200000, Jajan has no row this month
+			{"kebutuhan", "makan", 1000000, 1100000},
+		},
+		{ // March: both spend 300000 of their effective budget
+			{"Kebutuhan", "Makan", 1000000, 1100000},
+			{"Keinginan", "Jajan", 300000, 300000},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		makanMode string
+		jajanMode string
+		wantMakan float64
+		wantJajan float64
+	}{
+		{"surplus only", rolloverSurplus, rolloverSurplus, 0, 0},
+		{"surplus and deficit", rolloverSurplusAndDeficit, rolloverSurplusAndDeficit, 0, -50000},
+		{"mixed", rolloverSurplus, rolloverSurplusAndDeficit, 0, -50000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			carries := map[string]budgetCarry{
+				makan: {Mode: tt.makanMode},
+				jajan: {Mode: tt.jajanMode},
+			}
+			carryThrough(carries, blocks)
+			if got := carries[makan].CarryOver; got != tt.wantMakan {
+				t.Errorf("Makan carry = %v, want %v", got, tt.wantMakan)
+			}
+			if got := carries[jajan].CarryOver; got != tt.wantJajan {
+				t.Errorf("Jajan carry = %v, want %v", got, tt.wantJajan)
+			}
+		})
+	}
+
+	// Stopping after February: Makan carried 200000 in, spent 1100000 of 1200000
+	carries := map[string]budgetCarry{makan: {Mode: rolloverSurplus}}
+	carryThrough(carries, blocks[:2])
+	if got := carries[makan].CarryOver; got != 100000 {
+		t.Errorf("Makan carry after February = %v, want 100000", got)
+	}
+}
+
+func TestBudgetCarryOverChainsDecemberIntoJanuary(t *testing.T) {
+	repo, _ := newFakeSheetRepo(t, []string{masterDataSheet, "Januari", "November", "Desember"}, map[string][][]interface{}{
+		yearSpreadsheetSheet + "!A2:B": {{2023, "ss-2023"}, {2024, "ss-2024"}},
+		budgetRolloverSheet + "!A2:C":  {{"Kebutuhan", "Makan", rolloverSurplus}},
+		"ss-2023/November!P2:T":        {{"Kebutuhan", "Makan", 1000000, 900000}},
+		"ss-2023/Desember!P2:T":        {{"Kebutuhan", "Makan", 1000000, 850000}},
+		"ss-2024/Januari!P2:T":         {{"Kebutuhan", "Makan", 1000000, 1000000}},
+	})
+
+	carries, err := budgetCarryOver(repo, "ss-2024", "Januari")
+	if err != nil {
+		t.Fatalf("budgetCarryOver: %v", err)
+	}
+	// November leaves 100000, December leaves 1100000 - 850000
+	if got := carries[budgetKey("Kebutuhan", "Makan")]; got.Mode != rolloverSurplus || got.CarryOver != 250000 {
+		t.Errorf("carry into January = %+v, want surplus 250000", got)
+	}
+
+	carries, err = budgetCarryOver(repo, "ss-2024", "Februari")
+	if err != nil {
+		t.Fatalf("budgetCarryOver: %v", err)
+	}
+	if got := carries[budgetKey("Kebutuhan", "Makan")].CarryOver; got != 250000 {
+		t.Errorf("carry into February = %v, want 250000", got)
+	}
+}
+
+func TestGetCategoryReportsCarryOverOfTheMonthTab(t *testing.T) {
+	repo, _ := newFakeSheetRepo(t, []string{masterDataSheet, "Januari", "Februari"}, map[string][][]interface{}{
+		yearSpreadsheetSheet + "!A2:B": {{2024, "sheet-id"}},
+		budgetRolloverSheet + "!A2:C":  {{"Kebutuhan", "Makan", rolloverSurplus}},
+		masterDataSheet + "!A4:C":      {{"Kebutuhan", "Makan", 1000000}},
+		"Januari!P2:T":                 {{"Kebutuhan", "Makan", 1000000, 700000}},
+	})
+	uc := NewCategoryUsecase(repo, "IDR")
+
+	res, err := uc.GetCategory("sheet-id", masterDataSheet, "Februari")
+	if err != nil {
+		t.Fatalf("GetCategory: %v", err)
+	}
+	if len(res.Categories) != 1 || res.Categories[0].CarryOver != 300000 || res.Categories[0].EffectiveBudget != 1300000 {
+		t.Errorf("Categories = %+v, want Makan carrying 300000", res.Categories)
+	}
+
+	if _, err := uc.GetCategory("sheet-id", masterDataSheet, "Bulan"); !errors.Is(err, ErrNotMonthTab) {
+		t.Errorf("GetCategory(Bulan) error = %v, want ErrNotMonthTab", err)
+	}
+}
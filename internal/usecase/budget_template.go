@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"byeboros-backend/internal/adapter/http/model/request"
+	"byeboros-backend/internal/adapter/http/model/response"
+	"byeboros-backend/internal/adapter/repository"
+)
+
+// budgetTemplateSheet stores named budget templates, one row per sub-category. The daily and
+// monthly budgets of a template are repeated on each of its rows.
+const budgetTemplateSheet = "Budget Templates"
+
+var budgetTemplateHeaders = []interface{}{"Template", "Category", "Sub Category", "Budget", "Daily Budget", "Monthly Budget"}
+
+// ErrMonthHasTransactions is returned when applying a budget table to a month tab whose
+// expense block already holds transactions, which the table would overwrite
+var ErrMonthHasTransactions = errors.New("month tab already has expense transactions")
+
+// budgetTemplate is a named category/budget table
+type budgetTemplate struct {
+	Name  string
+	Rows  []int // 1-based rows in the templates sheet
+	Table request.SaveCategoryRequest
+}
+
+// loadBudgetTemplates reads every template keyed by lower-cased name
+func loadBudgetTemplates(sheetRepo *repository.SheetRepository, spreadsheetID string) (map[string]*budgetTemplate, error) {
+	rows, err := sheetRepo.GetOptionalRangeValuesUnformatted(spreadsheetID, budgetTemplateSheet+"!A2:F")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get budget templates: %w", err)
+	}
+
+	templates := make(map[string]*budgetTemplate)
+	for i, row := range rows {
+		name := cellString(row, 0)
+		if name == "" {
+			continue
+		}
+		key := strings.ToLower(name)
+		t, ok := templates[key]
+		if !ok {
+			t = &budgetTemplate{Name: name}
+			t.Table.Categories = make([]request.CategoryItem, 0)
+			templates[key] = t
+		}
+		t.Rows = append(t.Rows, i+2)
+		t.Table.DailyBudget = cellNumber(row, 4)
+		t.Table.MonthlyBudget = cellNumber(row, 5)
+
+		catName := cellString(row, 1)
+		subCatName := cellString(row, 2)
+		if catName == "" || subCatName == "" {
+			continue
+		}
+		t.Table.Categories = append(t.Table.Categories, request.CategoryItem{
+			CategoryName:    catName,
+			SubCategoryName: subCatName,
+			Budget:          cellNumber(row, 3),
+		})
+	}
+	return templates, nil
+}
+
+// GetBudgetTemplates returns every budget template
+func (u *BudgetUsecase) GetBudgetTemplates(spreadsheetID string) ([]response.BudgetTemplateItem, error) {
+	templates, err := loadBudgetTemplates(u.sheetRepo, spreadsheetID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]response.BudgetTemplateItem, 0, len(templates))
+	for _, t := range templates {
+		items = append(items, buildBudgetTemplateItem(t.Name, &t.Table))
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Name < items[j].Name
+	})
+	return items, nil
+}
+
+// SaveBudgetTemplate creates or replaces a named template, either from the given categories
+// or from the current table of a month tab
+func (u *BudgetUsecase) SaveBudgetTemplate(spreadsheetID string, req request.SaveBudgetTemplateRequest) (*response.BudgetTemplateItem, error) {
+	templates, err := loadBudgetTemplates(u.sheetRepo, spreadsheetID)
+	if err != nil {
+		return nil, err
+	}
+
+	table := &request.SaveCategoryRequest{
+		DailyBudget:   req.DailyBudget,
+		MonthlyBudget: req.MonthlyBudget,
+		Categories:    req.Categories,
+	}
+	if req.SourceSheet != "" {
+		table, err = readCategoryTable(u.sheetRepo, spreadsheetID, req.SourceSheet)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Replace an existing template with the same name
+	if existing, ok := templates[strings.ToLower(req.Name)]; ok {
+		for _, row := range existing.Rows {
+			rangeStr := fmt.Sprintf("%s!A%d:F%d", budgetTemplateSheet, row, row)
+			if err := u.sheetRepo.ClearRange(spreadsheetID, rangeStr); err != nil {
+				return nil, fmt.Errorf("failed to clear budget template %s: %w", existing.Name, err)
+			}
+		}
+	}
+
+	var rows [][]interface{}
+	for _, cat := range table.Categories {
+		rows = append(rows, []interface{}{req.Name, cat.CategoryName, cat.SubCategoryName, cat.Budget, table.DailyBudget, table.MonthlyBudget})
+	}
+	if len(rows) == 0 {
+		// Keep the template with only its daily and monthly budgets
+		rows = append(rows, []interface{}{req.Name, "", "", "", table.DailyBudget, table.MonthlyBudget})
+	}
+
+	if err := u.sheetRepo.EnsureSheet(spreadsheetID, budgetTemplateSheet, budgetTemplateHeaders); err != nil {
+		return nil, err
+	}
+	if err := u.sheetRepo.BatchAppendRows(spreadsheetID, budgetTemplateSheet+"!A:F", rows); err != nil {
+		return nil, fmt.Errorf("failed to save budget template: %w", err)
+	}
+
+	item := buildBudgetTemplateItem(req.Name, table)
+	return &item, nil
+}
+
+// ApplyBudget writes a template, or the budgets of the month before the target tab, into the
+// target tab's A4:C and F4:F5, optionally scaled by a percentage. Those cells share rows with
+// the expense block (A2:G), so a tab that already has expenses is refused.
+func (u *BudgetUsecase) ApplyBudget(spreadsheetID string, targetSheet string, req request.ApplyBudgetRequest) (*response.BudgetTemplateItem, error) {
+	rows, err := u.sheetRepo.GetRangeValues(spreadsheetID, targetSheet+"!A2:G")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions of %s: %w", targetSheet, err)
+	}
+	if len(parseExpenseRecords(targetSheet, rows)) > 0 {
+		return nil, fmt.Errorf("%w: apply the budget to %s before recording expenses", ErrMonthHasTransactions, targetSheet)
+	}
+
+	var source *request.SaveCategoryRequest
+	name := req.Template
+
+	if req.Template != "" {
+		templates, err := loadBudgetTemplates(u.sheetRepo, spreadsheetID)
+		if err != nil {
+			return nil, err
+		}
+		t, ok := templates[strings.ToLower(req.Template)]
+		if !ok {
+			return nil, fmt.Errorf("budget template '%s' not found", req.Template)
+		}
+		source = &t.Table
+	} else {
+		month := sheetMonth(targetSheet)
+		if month == 0 {
+			return nil, fmt.Errorf("%w: %s", ErrNotMonthTab, targetSheet)
+		}
+
+		// A spreadsheet holds a single year, so January reads December of the previous year
+		sourceID, sourceMonth := spreadsheetID, month-1
+		if month == 1 {
+			year, err := spreadsheetYear(u.sheetRepo, spreadsheetID)
+			if err != nil {
+				return nil, err
+			}
+			years, err := loadYearSpreadsheets(u.sheetRepo, spreadsheetID)
+			if err != nil {
+				return nil, err
+			}
+			ys, ok := years[year-1]
+			if !ok {
+				return nil, fmt.Errorf("no spreadsheet linked for %d, link it via /api/spreadsheets", year-1)
+			}
+			sourceID, sourceMonth = ys.SpreadsheetID, 12
+		}
+
+		name = getIndonesianMonthName(sourceMonth)
+		table, err := readCategoryTable(u.sheetRepo, sourceID, name)
+		if err != nil {
+			return nil, err
+		}
+		source = table
+	}
+
+	scale := 1.0
+	if req.ScalePercent != 0 {
+		scale = req.ScalePercent / 100
+	}
+
+	table := &request.SaveCategoryRequest{
+		DailyBudget:   math.Round(source.DailyBudget * scale),
+		MonthlyBudget: math.Round(source.MonthlyBudget * scale),
+		Categories:    make([]request.CategoryItem, 0, len(source.Categories)),
+	}
+	for _, cat := range source.Categories {
+		cat.Budget = math.Round(cat.Budget * scale)
+		table.Categories = append(table.Categories, cat)
+	}
+
+	if err := writeCategoryTable(u.sheetRepo, spreadsheetID, targetSheet, table); err != nil {
+		return nil, err
+	}
+
+	item := buildBudgetTemplateItem(name, table)
+	return &item, nil
+}
+
+func buildBudgetTemplateItem(name string, table *request.SaveCategoryRequest) response.BudgetTemplateItem {
+	item := response.BudgetTemplateItem{
+		Name:          name,
+		DailyBudget:   table.DailyBudget,
+		MonthlyBudget: table.MonthlyBudget,
+		Categories:    make([]response.BudgetTemplateCategory, 0, len(table.Categories)),
+	}
+	for _, cat := range table.Categories {
+		item.Categories = append(item.Categories, response.BudgetTemplateCategory{
+			CategoryName:    cat.CategoryName,
+			SubCategoryName: cat.SubCategoryName,
+			Budget:          cat.Budget,
+		})
+		item.TotalBudget += cat.Budget
+	}
+	return item
+}
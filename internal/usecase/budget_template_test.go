@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"byeboros-backend/internal/adapter/http/model/request"
+)
+
+func TestApplyBudgetRefusesMonthWithExpenses(t *testing.T) {
+	repo, fake := newFakeSheetRepo(t, []string{masterDataSheet, "Maret", "April"}, map[string][][]interface{}{
+		"Maret!A4:C":  {{"Kebutuhan", "Makan", 1000000}},
+		"Maret!F4:F5": {{50000}, {3000000}},
+		"April!A2:G": {
+			{"Nasi padang", "Makan", "Need", 25000, "", "2024-04-02 12:00", "budi"},
+		},
+	})
+
+	_, err := NewBudgetUsecase(repo, "IDR").ApplyBudget("sheet-id", "April", request.ApplyBudgetRequest{})
+	if !errors.Is(err, ErrMonthHasTransactions) {
+		t.Fatalf("ApplyBudget error = %v, want ErrMonthHasTransactions", err)
+	}
+	if len(fake.writes) > 0 || len(fake.cleared) > 0 {
+		t.Errorf("ApplyBudget wrote %v and cleared %v, want no change", fake.writes, fake.cleared)
+	}
+}
+
+func TestApplyBudgetCopiesPreviousMonthIntoEmptyTab(t *testing.T) {
+	repo, fake := newFakeSheetRepo(t, []string{masterDataSheet, "Maret", "April"}, map[string][][]interface{}{
+		"Maret!A4:C":  {{"Kebutuhan", "Makan", 1000000}, {"Keinginan", "Jajan", 300000}},
+		"Maret!F4:F5": {{50000}, {3000000}},
+		// A table applied earlier: no amount or date, so not an expense
+		"April!A2:G": {{}, {}, {"Kebutuhan", "Makan", 900000, "", "", 45000}},
+	})
+
+	item, err := NewBudgetUsecase(repo, "IDR").ApplyBudget("sheet-id", "April", request.ApplyBudgetRequest{ScalePercent: 110})
+	if err != nil {
+		t.Fatalf("ApplyBudget: %v", err)
+	}
+	if item.Name != "Maret" || item.TotalBudget != 1430000 {
+		t.Errorf("item = %+v, want Maret with 1430000 in total", item)
+	}
+
+	want := map[string][][]interface{}{
+		"April!F4:F5": {{float64(55000)}, {float64(3300000)}},
+		"April!A4:C5": {{"Kebutuhan", "Makan", float64(1100000)}, {"Keinginan", "Jajan", float64(330000)}},
+	}
+	if !reflect.DeepEqual(fake.writes, want) {
+		t.Errorf("writes = %v, want %v", fake.writes, want)
+	}
+	if !reflect.DeepEqual(fake.cleared, []string{"April!A6:C"}) {
+		t.Errorf("cleared = %v, want [April!A6:C]", fake.cleared)
+	}
+}
@@ -0,0 +1,293 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"byeboros-backend/internal/adapter/http/model/response"
+	"byeboros-backend/internal/adapter/repository"
+)
+
+const (
+	// budgetWarningMargin is how many percentage points spending may run ahead of the
+	// month's progress before a sub-category is flagged as warning
+	budgetWarningMargin = 10.0
+
+	// budgetWarningPercent flags a sub-category as warning once this share of its budget is used
+	budgetWarningPercent = 90.0
+)
+
+// ErrDateOutsideMonth is returned when a date does not fall in the month of the requested tab
+var ErrDateOutsideMonth = errors.New("date is outside the month of the sheet")
+
+// BudgetUsecase compares the configured budgets of a month tab with actual spending
+type BudgetUsecase struct {
+	sheetRepo    *repository.SheetRepository
+	baseCurrency string // currency stored amounts (columns D and K) are expressed in
+}
+
+// NewBudgetUsecase creates a new BudgetUsecase
+func NewBudgetUsecase(sheetRepo *repository.SheetRepository, baseCurrency string) *BudgetUsecase {
+	return &BudgetUsecase{sheetRepo: sheetRepo, baseCurrency: baseCurrency}
+}
+
+// sheetMonth returns the month number (1-12) of an Indonesian month tab name, or 0
+func sheetMonth(sheetName string) int {
+	for month := 1; month <= 12; month++ {
+		if strings.EqualFold(getIndonesianMonthName(month), strings.TrimSpace(sheetName)) {
+			return month
+		}
+	}
+	return 0
+}
+
+// monthProgress returns how far through the month of a tab of the given year we are, from
+// 0 to 1. Tabs of earlier months are complete, later months have not started yet.
+func monthProgress(sheetName string, year int, now time.Time) float64 {
+	month := sheetMonth(sheetName)
+	if month == 0 {
+		year, month = now.Year(), int(now.Month())
+	}
+
+	current := now.Year()*12 + int(now.Month())
+	switch tab := year*12 + month; {
+	case tab < current:
+		return 1
+	case tab > current:
+		return 0
+	}
+	daysInMonth := time.Date(now.Year(), now.Month()+1, 0, 0, 0, 0, 0, now.Location()).Day()
+	return float64(now.Day()) / float64(daysInMonth)
+}
+
+// budgetStatus classifies spending against a budget given the month progress (0-1)
+func budgetStatus(budget, spent, progress float64) string {
+	if spent > budget {
+		return "over"
+	}
+	if budget == 0 {
+		return "on_track"
+	}
+	percentUsed := spent / budget * 100
+	if percentUsed >= budgetWarningPercent || percentUsed > progress*100+budgetWarningMargin {
+		return "warning"
+	}
+	return "on_track"
+}
+
+// GetBudgetStatus returns budget vs actual spending per sub-category from the P2:T block of
+// the month tab, with the monthly budget of the master data (F5)
+func (u *BudgetUsecase) GetBudgetStatus(spreadsheetID string, sheetName string) (*response.BudgetStatusResponse, error) {
+	ranges := []string{
+		sheetName + "!P2:T",        // 0: Nama Kategori, Sub kategori, Budget, Alokasi, Sisa
+		masterDataSheet + "!F4:F5", // 1: daily_budget, monthly_budget
+	}
+
+	valueRanges, err := u.sheetRepo.BatchGetValues(spreadsheetID, ranges)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch budget data: %w", err)
+	}
+
+	getVal := func(idx int) [][]interface{} {
+		if len(valueRanges) > idx && valueRanges[idx] != nil {
+			return valueRanges[idx].Values
+		}
+		return [][]interface{}{}
+	}
+
+	carries, err := budgetCarryOver(u.sheetRepo, spreadsheetID, sheetName)
+	if err != nil {
+		return nil, err
+	}
+
+	year, err := spreadsheetYear(u.sheetRepo, spreadsheetID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	progress := monthProgress(sheetName, year, now)
+
+	res := &response.BudgetStatusResponse{
+		SheetName:     sheetName,
+		MonthProgress: math.Round(progress*1000) / 10,
+		Categories:    make([]response.BudgetStatusItem, 0),
+	}
+
+	budgetRows := getVal(1)
+	if len(budgetRows) > 1 && len(budgetRows[1]) > 0 {
+		res.MonthlyBudget = parseAmount(budgetRows[1][0])
+	}
+
+	for _, row := range getVal(0) {
+		if len(row) < 3 {
+			continue
+		}
+		catName := strings.TrimSpace(fmt.Sprintf("%v", row[0]))
+		subCatName := strings.TrimSpace(fmt.Sprintf("%v", row[1]))
+		if catName == "" || strings.EqualFold(catName, "Nama Kategori") || strings.EqualFold(catName, "Category") {
+			continue
+		}
+
+		budget := parseAmount(row[2])
+		var spent float64
+		if len(row) > 3 {
+			spent = parseAmount(row[3])
+		}
+
+		item := buildBudgetStatusItem(catName, subCatName, budget, carries[budgetKey(catName, subCatName)], spent, progress, u.baseCurrency)
+		res.Categories = append(res.Categories, item)
+		res.TotalBudget += item.EffectiveBudget
+		res.TotalSpent += spent
+	}
+
+	res.TotalRemaining = res.TotalBudget - res.TotalSpent
+	if res.TotalBudget > 0 {
+		res.PercentUsed = math.Round(res.TotalSpent/res.TotalBudget*1000) / 10
+	}
+	res.Status = budgetStatus(res.TotalBudget, res.TotalSpent, progress)
+	res.TotalBudgetDisplay = formatBalance(res.TotalBudget, u.baseCurrency)
+	res.TotalSpentDisplay = formatBalance(res.TotalSpent, u.baseCurrency)
+	res.TotalRemainingDisplay = formatBalance(res.TotalRemaining, u.baseCurrency)
+	return res, nil
+}
+
+// buildBudgetStatusItem measures spending against the effective budget: the configured
+// budget plus whatever rolled over from the previous months
+func buildBudgetStatusItem(catName, subCatName string, budget float64, carry budgetCarry, spent, progress float64, baseCurrency string) response.BudgetStatusItem {
+	effective := budget + carry.CarryOver
+	item := response.BudgetStatusItem{
+		CategoryName:           catName,
+		SubCategoryName:        subCatName,
+		Budget:                 budget,
+		BudgetDisplay:          formatBalance(budget, baseCurrency),
+		RolloverMode:           carry.Mode,
+		CarryOver:              carry.CarryOver,
+		EffectiveBudget:        effective,
+		EffectiveBudgetDisplay: formatBalance(effective, baseCurrency),
+		Spent:                  spent,
+		SpentDisplay:           formatBalance(spent, baseCurrency),
+		Remaining:              effective - spent,
+		RemainingDisplay:       formatBalance(effective-spent, baseCurrency),
+		Status:                 budgetStatus(effective, spent, progress),
+	}
+	if item.RolloverMode == "" {
+		item.RolloverMode = rolloverNone
+	}
+	if effective > 0 {
+		item.PercentUsed = math.Round(spent/effective*1000) / 10
+	}
+	return item
+}
+
+// dailyBudgetDay returns the day to report for a month tab of the given year: the requested
+// date, which must fall in the tab's month, or else today, or the last day of the tab's
+// month when today is in another month
+func dailyBudgetDay(sheetName string, year int, date string, now time.Time) (time.Time, error) {
+	month := sheetMonth(sheetName)
+	if month == 0 {
+		return time.Time{}, fmt.Errorf("%w: %s is not a month tab", ErrDateOutsideMonth, sheetName)
+	}
+
+	if date == "" {
+		if now.Year() == year && int(now.Month()) == month {
+			return now, nil
+		}
+		return time.Date(year, time.Month(month)+1, 0, 0, 0, 0, 0, now.Location()), nil
+	}
+
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date: %w", err)
+	}
+	if day.Year() != year || int(day.Month()) != month {
+		return time.Time{}, fmt.Errorf("%w: %s is not in %s %d", ErrDateOutsideMonth, date, sheetName, year)
+	}
+	return day, nil
+}
+
+// GetDailyBudget returns a day's allowance from the daily_budget setting (F4 of the master
+// data) and the expenses of the month tab. Whatever was left of a day's allowance is carried
+// over to the next day, so underspending grows the allowance while overspending only resets
+// it to the daily budget.
+func (u *BudgetUsecase) GetDailyBudget(spreadsheetID string, sheetName string, date string) (*response.DailyBudgetResponse, error) {
+	year, err := spreadsheetYear(u.sheetRepo, spreadsheetID)
+	if err != nil {
+		return nil, err
+	}
+	day, err := dailyBudgetDay(sheetName, year, date, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	ranges := []string{
+		masterDataSheet + "!F4", // 0: daily_budget
+		sheetName + "!A2:G",     // 1: expense transactions
+	}
+
+	valueRanges, err := u.sheetRepo.BatchGetValues(spreadsheetID, ranges)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch daily budget data: %w", err)
+	}
+
+	getVal := func(idx int) [][]interface{} {
+		if len(valueRanges) > idx && valueRanges[idx] != nil {
+			return valueRanges[idx].Values
+		}
+		return [][]interface{}{}
+	}
+
+	var dailyBudget float64
+	if rows := getVal(0); len(rows) > 0 && len(rows[0]) > 0 {
+		dailyBudget = parseAmount(rows[0][0])
+	}
+
+	// Sum the expenses of each day of the month up to the requested day
+	spentByDay := make(map[int]float64)
+	for _, record := range parseExpenseRecords(sheetName, getVal(1)) {
+		if record.Time.Year() != day.Year() || record.Time.Month() != day.Month() || record.Time.Day() > day.Day() {
+			continue
+		}
+		spentByDay[record.Time.Day()] += record.Amount
+	}
+
+	res := &response.DailyBudgetResponse{
+		Date:        day.Format("2006-01-02"),
+		DailyBudget: dailyBudget,
+		Days:        make([]response.DailyBudgetDay, 0, day.Day()),
+	}
+
+	var carryOver float64
+	for d := 1; d <= day.Day(); d++ {
+		allowance := dailyBudget + carryOver
+		spent := spentByDay[d]
+		remaining := allowance - spent
+
+		res.Days = append(res.Days, response.DailyBudgetDay{
+			Date:      time.Date(day.Year(), day.Month(), d, 0, 0, 0, 0, day.Location()).Format("2006-01-02"),
+			Allowance: allowance,
+			CarryOver: carryOver,
+			Spent:     spent,
+			Remaining: remaining,
+		})
+
+		if d == day.Day() {
+			res.CarryOver = carryOver
+			res.Allowance = allowance
+			res.SpentToday = spent
+			res.RemainingToday = remaining
+		}
+		carryOver = math.Max(remaining, 0)
+	}
+
+	res.Status = "on_track"
+	if res.RemainingToday < 0 {
+		res.Status = "over"
+	}
+	res.AllowanceDisplay = formatBalance(res.Allowance, u.baseCurrency)
+	res.SpentTodayDisplay = formatBalance(res.SpentToday, u.baseCurrency)
+	res.RemainingTodayDisplay = formatBalance(res.RemainingToday, u.baseCurrency)
+	return res, nil
+}
@@ -0,0 +1,176 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDailyBudgetDay(t *testing.T) {
+	now := time.Date(2024, 3, 13, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		sheet   string
+		year    int
+		date    string
+		want    string
+		wantErr error
+	}{
+		{"today in the current tab", "Maret", 2024, "", "2024-03-13", nil},
+		{"last day of a past tab", "Februari", 2024, "", "2024-02-29", nil},
+		{"same month of another year", "Maret", 2023, "", "2023-03-31", nil},
+		{"date in the tab", "Februari", 2024, "2024-02-10", "2024-02-10", nil},
+		{"date in another month", "Februari", 2024, "2024-03-10", "", ErrDateOutsideMonth},
+		{"date in another year", "Maret", 2024, "2023-03-10", "", ErrDateOutsideMonth},
+		{"not a month tab", masterDataSheet, 2024, "2024-03-10", "", ErrDateOutsideMonth},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := dailyBudgetDay(tt.sheet, tt.year, tt.date, now)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.Format("2006-01-02") != tt.want {
+				t.Errorf("day = %s, want %s", got.Format("2006-01-02"), tt.want)
+			}
+		})
+	}
+}
+
+func TestGetDailyBudgetReadsBudgetFromMasterData(t *testing.T) {
+	repo, fake := newFakeSheetRepo(t, []string{masterDataSheet, "Maret"}, map[string][][]interface{}{
+		yearSpreadsheetSheet + "!A2:B": {{2024, "sheet-id"}},
+		masterDataSheet + "!F4":        {{100000}},
+		"Maret!A2:G": {
+			{"Nasi padang", "Makan", "Need", 30000, "", "2024-03-01 12:00", "budi"},
+			{"Bensin", "Transport", "Need", 150000, "", "2024-03-02 08:00", "budi"},
+			{"Kopi", "Jajan", "Want", 20000, "", "2024-03-03 15:00", "budi"},
+		},
+	})
+
+	res, err := NewBudgetUsecase(repo, "IDR").GetDailyBudget("sheet-id", "Maret", "2024-03-03")
+	if err != nil {
+		t.Fatalf("GetDailyBudget: %v", err)
+	}
+	if fake.read("Maret!F4") {
+		t.Error("daily budget was read from the month tab's expense rows")
+	}
+	if res.DailyBudget != 100000 {
+		t.Errorf("DailyBudget = %v, want 100000", res.DailyBudget)
+	}
+	// Day 1 leaves 70000, day 2 spends 150000 of 170000 and carries 20000 into day 3
+	if res.Allowance != 120000 || res.SpentToday != 20000 || res.RemainingToday != 100000 {
+		t.Errorf("allowance %v, spent %v, remaining %v, want 120000, 20000, 100000", res.Allowance, res.SpentToday, res.RemainingToday)
+	}
+	if len(res.Days) != 3 || res.Days[1].Allowance != 170000 {
+		t.Errorf("Days = %+v, want 3 days with 170000 allowed on the second", res.Days)
+	}
+}
+
+func TestMonthProgress(t *testing.T) {
+	now := time.Date(2024, 3, 15, 10, 0, 0, 0, time.UTC) // 15 of 31 days
+	tests := []struct {
+		sheet string
+		year  int
+		want  float64
+	}{
+		{"Maret", 2024, 15.0 / 31},
+		{"Februari", 2024, 1},
+		{"April", 2024, 0},
+		{"Desember", 2023, 1},              // a past year is complete even for a later month
+		{"Januari", 2025, 0},               // a future year has not started
+		{masterDataSheet, 2024, 15.0 / 31}, // not a month tab: the current month
+	}
+	for _, tt := range tests {
+		if got := monthProgress(tt.sheet, tt.year, now); got != tt.want {
+			t.Errorf("monthProgress(%s, %d) = %v, want %v", tt.sheet, tt.year, got, tt.want)
+		}
+	}
+}
+
+func TestBudgetStatus(t *testing.T) {
+	tests := []struct {
+		name                    string
+		budget, spent, progress float64
+		want                    string
+	}{
+		{"over budget", 100000, 100001, 1, "over"},
+		{"fully used", 100000, 100000, 1, "warning"},
+		{"90 percent used", 100000, 90000, 1, "warning"},
+		{"on pace", 100000, 50000, 0.5, "on_track"},
+		{"within the margin", 100000, 60000, 0.5, "on_track"},
+		{"ahead of the month", 100000, 61000, 0.5, "warning"},
+		{"no budget, no spending", 0, 0, 0.5, "on_track"},
+		{"no budget, some spending", 0, 1, 0.5, "over"},
+	}
+	for _, tt := range tests {
+		if got := budgetStatus(tt.budget, tt.spent, tt.progress); got != tt.want {
+			t.Errorf("%s: budgetStatus(%v, %v, %v) = %s, want %s", tt.name, tt.budget, tt.spent, tt.progress, got, tt.want)
+		}
+	}
+}
+
+func TestBuildBudgetStatusItem(t *testing.T) {
+	tests := []struct {
+		name          string
+		carry         budgetCarry
+		spent         float64
+		wantEffective float64
+		wantRemaining float64
+		wantPercent   float64
+		wantMode      string
+		wantStatus    string
+	}{
+		{"no rollover", budgetCarry{}, 300000, 500000, 200000, 60, rolloverNone, "on_track"},
+		{"surplus carried in", budgetCarry{Mode: rolloverSurplus, CarryOver: 100000}, 550000, 600000, 50000, 91.7, rolloverSurplus, "warning"},
+		{"deficit carried in", budgetCarry{Mode: rolloverSurplusAndDeficit, CarryOver: -200000}, 350000, 300000, -50000, 116.7, rolloverSurplusAndDeficit, "over"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := buildBudgetStatusItem("Kebutuhan", "Makan", 500000, tt.carry, tt.spent, 0.8, "IDR")
+			if item.EffectiveBudget != tt.wantEffective || item.Remaining != tt.wantRemaining {
+				t.Errorf("effective %v, remaining %v, want %v, %v", item.EffectiveBudget, item.Remaining, tt.wantEffective, tt.wantRemaining)
+			}
+			if item.PercentUsed != tt.wantPercent {
+				t.Errorf("PercentUsed = %v, want %v", item.PercentUsed, tt.wantPercent)
+			}
+			if item.RolloverMode != tt.wantMode || item.Status != tt.wantStatus {
+				t.Errorf("mode %s, status %s, want %s, %s", item.RolloverMode, item.Status, tt.wantMode, tt.wantStatus)
+			}
+			if item.Budget != 500000 || item.CarryOver != tt.carry.CarryOver {
+				t.Errorf("budget %v, carry %v, want 500000, %v", item.Budget, item.CarryOver, tt.carry.CarryOver)
+			}
+		})
+	}
+}
+
+func TestGetBudgetStatusReadsMonthlyBudgetFromMasterData(t *testing.T) {
+	repo, fake := newFakeSheetRepo(t, []string{masterDataSheet, "Maret"}, map[string][][]interface{}{
+		yearSpreadsheetSheet + "!A2:B": {{2024, "sheet-id"}},
+		masterDataSheet + "!F4:F5":     {{100000}, {3000000}},
+		"Maret!P2:T": {
+			{"Nama Kategori", "Sub kategori", "Budget", "Alokasi", "Sisa"},
+			{"Kebutuhan", "Makan", 1500000, 1200000, 300000},
+		},
+	})
+
+	res, err := NewBudgetUsecase(repo, "IDR").GetBudgetStatus("sheet-id", "Maret")
+	if err != nil {
+		t.Fatalf("GetBudgetStatus: %v", err)
+	}
+	if fake.read("Maret!F4:F5") {
+		t.Error("monthly budget was read from the month tab's expense rows")
+	}
+	if res.MonthlyBudget != 3000000 {
+		t.Errorf("MonthlyBudget = %v, want 3000000", res.MonthlyBudget)
+	}
+	if len(res.Categories) != 1 || res.TotalSpent != 1200000 {
+		t.Errorf("Categories = %+v, TotalSpent = %v, want one category with 1200000 spent", res.Categories, res.TotalSpent)
+	}
+}
@@ -3,6 +3,7 @@ package usecase
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"byeboros-backend/internal/adapter/http/model/request"
 	"byeboros-backend/internal/adapter/http/model/response"
@@ -10,36 +11,87 @@ import (
 )
 
 type CategoryUsecase struct {
-	sheetRepo *repository.SheetRepository
+	sheetRepo    *repository.SheetRepository
+	baseCurrency string // currency stored amounts (columns D and K) are expressed in
 }
 
-func NewCategoryUsecase(sheetRepo *repository.SheetRepository) *CategoryUsecase {
-	return &CategoryUsecase{sheetRepo: sheetRepo}
+func NewCategoryUsecase(sheetRepo *repository.SheetRepository, baseCurrency string) *CategoryUsecase {
+	return &CategoryUsecase{sheetRepo: sheetRepo, baseCurrency: baseCurrency}
 }
 
-func (u *CategoryUsecase) GetCategory(spreadsheetID string, sheetName string) (*response.CategoryResponse, error) {
+// GetCategory returns the category table of the category sheet, with the budget carried into
+// monthSheet by rollover. monthSheet defaults to the category sheet itself when it is a month
+// tab, otherwise to the tab of the current month.
+func (u *CategoryUsecase) GetCategory(spreadsheetID string, sheetName string, monthSheet string) (*response.CategoryResponse, error) {
+	if monthSheet == "" {
+		monthSheet = sheetName
+		if sheetMonth(sheetName) == 0 {
+			monthSheet = getIndonesianMonthName(int(time.Now().Month()))
+		}
+	} else if sheetMonth(monthSheet) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNotMonthTab, monthSheet)
+	}
+
+	table, err := readCategoryTable(u.sheetRepo, spreadsheetID, sheetName)
+	if err != nil {
+		return nil, err
+	}
+
+	carries, err := budgetCarryOver(u.sheetRepo, spreadsheetID, monthSheet)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &response.CategoryResponse{
+		DailyBudget:   table.DailyBudget,
+		MonthlyBudget: table.MonthlyBudget,
+		Categories:    make([]response.CategoryItem, 0, len(table.Categories)),
+	}
+
+	for _, cat := range table.Categories {
+		carry := carries[budgetKey(cat.CategoryName, cat.SubCategoryName)]
+		if carry.Mode == "" {
+			carry.Mode = rolloverNone
+		}
+
+		res.Categories = append(res.Categories, response.CategoryItem{
+			CategoryName:    cat.CategoryName,
+			SubCategoryName: cat.SubCategoryName,
+			Budget:          cat.Budget,
+			RolloverMode:    carry.Mode,
+			CarryOver:       carry.CarryOver,
+			EffectiveBudget: cat.Budget + carry.CarryOver,
+		})
+	}
+
+	return res, nil
+}
+
+// readCategoryTable reads the category/budget table (A4:C) and the daily and monthly
+// budgets (F4:F5) of a month tab
+func readCategoryTable(sheetRepo *repository.SheetRepository, spreadsheetID string, sheetName string) (*request.SaveCategoryRequest, error) {
 	catRange := sheetName + "!A4:C"
 	budgetRange := sheetName + "!F4:F5"
 
-	catRows, err := u.sheetRepo.GetRangeValues(spreadsheetID, catRange)
+	catRows, err := sheetRepo.GetRangeValues(spreadsheetID, catRange)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get categories from spreadsheet: %w", err)
 	}
 
-	budgetRows, err := u.sheetRepo.GetRangeValues(spreadsheetID, budgetRange)
+	budgetRows, err := sheetRepo.GetRangeValues(spreadsheetID, budgetRange)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get budgets from spreadsheet: %w", err)
 	}
 
-	res := &response.CategoryResponse{
-		Categories: make([]response.CategoryItem, 0),
+	table := &request.SaveCategoryRequest{
+		Categories: make([]request.CategoryItem, 0),
 	}
 
 	if len(budgetRows) > 0 && len(budgetRows[0]) > 0 {
-		res.DailyBudget = parseAmount(budgetRows[0][0])
+		table.DailyBudget = parseAmount(budgetRows[0][0])
 	}
 	if len(budgetRows) > 1 && len(budgetRows[1]) > 0 {
-		res.MonthlyBudget = parseAmount(budgetRows[1][0])
+		table.MonthlyBudget = parseAmount(budgetRows[1][0])
 	}
 
 	for _, row := range catRows {
@@ -56,19 +108,36 @@ func (u *CategoryUsecase) GetCategory(spreadsheetID string, sheetName string) (*
 			continue
 		}
 
-		budget := parseAmount(row[2])
+		var budget float64
+		if len(row) > 2 {
+			budget = parseAmount(row[2])
+		}
 
-		res.Categories = append(res.Categories, response.CategoryItem{
+		table.Categories = append(table.Categories, request.CategoryItem{
 			CategoryName:    category_name,
 			SubCategoryName: sub_category_name,
 			Budget:          budget,
 		})
 	}
 
-	return res, nil
+	return table, nil
 }
 
+// GetIncomeCategory returns the income categories of the master data tab, falling back to
+// the month tab's own H4:H list for spreadsheets without master income categories
 func (u *CategoryUsecase) GetIncomeCategory(spreadsheetID string, sheetName string) ([]string, error) {
+	master, _, err := loadIncomeCategoryRows(u.sheetRepo, spreadsheetID)
+	if err != nil {
+		return nil, err
+	}
+	if len(master) > 0 {
+		categories := make([]string, 0, len(master))
+		for _, cat := range master {
+			categories = append(categories, cat.Name)
+		}
+		return categories, nil
+	}
+
 	incomeRange := sheetName + "!H4:H"
 
 	rows, err := u.sheetRepo.GetRangeValues(spreadsheetID, incomeRange)
@@ -92,31 +161,279 @@ func (u *CategoryUsecase) GetIncomeCategory(spreadsheetID string, sheetName stri
 }
 
 func (u *CategoryUsecase) SaveCategory(spreadsheetID string, sheetName string, req *request.SaveCategoryRequest) error {
-	err := u.sheetRepo.UpdateCell(spreadsheetID, sheetName, 4, 5, req.DailyBudget)
+	return writeCategoryTable(u.sheetRepo, spreadsheetID, sheetName, req)
+}
+
+// writeCategoryTable replaces the category/budget table and the daily and monthly budgets of a month tab
+func writeCategoryTable(sheetRepo *repository.SheetRepository, spreadsheetID string, sheetName string, req *request.SaveCategoryRequest) error {
+	// Write the new table over the old one in a single request, then clear the rows left
+	// over from a longer old table, so a failed write never leaves the table empty
+	updates := map[string][][]interface{}{
+		sheetName + "!F4:F5": {{req.DailyBudget}, {req.MonthlyBudget}},
+	}
+	if len(req.Categories) > 0 {
+		var values [][]interface{}
+		for _, cat := range req.Categories {
+			values = append(values, []interface{}{cat.CategoryName, cat.SubCategoryName, cat.Budget})
+		}
+		updates[fmt.Sprintf("%s!A4:C%d", sheetName, len(values)+3)] = values
+	}
+	if err := sheetRepo.BatchUpdateRanges(spreadsheetID, updates); err != nil {
+		return fmt.Errorf("failed to save new categories: %w", err)
+	}
+
+	if err := sheetRepo.ClearRange(spreadsheetID, fmt.Sprintf("%s!A%d:C", sheetName, len(req.Categories)+4)); err != nil {
+		return fmt.Errorf("failed to clear old categories: %w", err)
+	}
+	return nil
+}
+
+// categoryRow is one row of the category/budget table (A4:C) of a month tab
+type categoryRow struct {
+	Row             int // 1-based sheet row
+	CategoryName    string
+	SubCategoryName string
+	Budget          float64
+}
+
+// loadCategoryRows reads the category table with the sheet row of each entry
+func loadCategoryRows(sheetRepo *repository.SheetRepository, spreadsheetID string, sheetName string) ([]categoryRow, error) {
+	rows, err := sheetRepo.GetRangeValues(spreadsheetID, sheetName+"!A4:C")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories from spreadsheet: %w", err)
+	}
+
+	var categories []categoryRow
+	for i, row := range rows {
+		catName := strings.TrimSpace(cellString(row, 0))
+		subCatName := strings.TrimSpace(cellString(row, 1))
+		if catName == "" && subCatName == "" {
+			continue
+		}
+
+		var budget float64
+		if len(row) > 2 {
+			budget = parseAmount(row[2])
+		}
+		categories = append(categories, categoryRow{
+			Row:             i + 4,
+			CategoryName:    catName,
+			SubCategoryName: subCatName,
+			Budget:          budget,
+		})
+	}
+	return categories, nil
+}
+
+// findCategoryRows returns the rows of a sub-category, or of every sub-category of a
+// category when subCategoryName is empty (case-insensitive)
+func findCategoryRows(categories []categoryRow, categoryName string, subCategoryName string) []categoryRow {
+	var found []categoryRow
+	for _, cat := range categories {
+		if subCategoryName != "" {
+			if strings.EqualFold(cat.SubCategoryName, subCategoryName) &&
+				(categoryName == "" || strings.EqualFold(cat.CategoryName, categoryName)) {
+				found = append(found, cat)
+			}
+			continue
+		}
+		if strings.EqualFold(cat.CategoryName, categoryName) {
+			found = append(found, cat)
+		}
+	}
+	return found
+}
+
+// AddCategoryItem adds a single sub-category to the category table of a month tab. The row
+// is appended by the Sheets API, so concurrent adds never write to the same row.
+func (u *CategoryUsecase) AddCategoryItem(spreadsheetID string, sheetName string, req request.CategoryItem) error {
+	categories, err := loadCategoryRows(u.sheetRepo, spreadsheetID, sheetName)
 	if err != nil {
 		return err
 	}
 
-	err = u.sheetRepo.UpdateCell(spreadsheetID, sheetName, 5, 5, req.MonthlyBudget)
+	// Transactions reference sub-categories by name only, so they must be unique
+	if existing := findCategoryRows(categories, "", req.SubCategoryName); len(existing) > 0 {
+		return fmt.Errorf("sub-category '%s' already exists in category '%s'", existing[0].SubCategoryName, existing[0].CategoryName)
+	}
+
+	values := [][]interface{}{{req.CategoryName, req.SubCategoryName, req.Budget}}
+	row, err := u.sheetRepo.AppendRowsAt(spreadsheetID, sheetName+"!A4:C", values)
+	if err != nil {
+		return fmt.Errorf("failed to add category: %w", err)
+	}
+
+	// Another request may have added the same sub-category meanwhile; the first row wins
+	categories, err = loadCategoryRows(u.sheetRepo, spreadsheetID, sheetName)
 	if err != nil {
 		return err
 	}
+	for _, other := range findCategoryRows(categories, "", req.SubCategoryName) {
+		if other.Row < row {
+			if err := u.sheetRepo.ClearRange(spreadsheetID, fmt.Sprintf("%s!A%d:C%d", sheetName, row, row)); err != nil {
+				return fmt.Errorf("failed to remove duplicate category: %w", err)
+			}
+			return fmt.Errorf("sub-category '%s' already exists in category '%s'", other.SubCategoryName, other.CategoryName)
+		}
+	}
+	return nil
+}
 
-	err = u.sheetRepo.ClearRange(spreadsheetID, sheetName+"!A4:C")
+// UpdateCategoryItem renames and/or re-budgets a sub-category, or renames a whole category
+// when no sub-category is given. Renaming a sub-category also renames it on the expense rows
+// (column B) of every month tab so they keep matching the budget block, and any rename
+// carries over to the rollover settings and budget templates keyed on the old name. It
+// returns the number of renamed transactions.
+func (u *CategoryUsecase) UpdateCategoryItem(spreadsheetID string, sheetName string, req request.UpdateCategoryItemRequest) (int, error) {
+	categories, err := loadCategoryRows(u.sheetRepo, spreadsheetID, sheetName)
 	if err != nil {
-		return fmt.Errorf("failed to clear existing categories: %w", err)
+		return 0, err
 	}
 
-	if len(req.Categories) > 0 {
-		var values [][]interface{}
-		for _, cat := range req.Categories {
-			values = append(values, []interface{}{cat.CategoryName, cat.SubCategoryName, cat.Budget})
+	targets := findCategoryRows(categories, req.CategoryName, req.SubCategoryName)
+	if len(targets) == 0 {
+		return 0, fmt.Errorf("category '%s' not found", categoryLabel(req.CategoryName, req.SubCategoryName))
+	}
+
+	renameSub := req.SubCategoryName != "" && req.NewSubCategoryName != "" &&
+		req.NewSubCategoryName != targets[0].SubCategoryName
+	if renameSub {
+		for _, other := range findCategoryRows(categories, "", req.NewSubCategoryName) {
+			if other.Row != targets[0].Row {
+				return 0, fmt.Errorf("sub-category '%s' already exists in category '%s'", other.SubCategoryName, other.CategoryName)
+			}
+		}
+	}
+
+	renamed := make(map[string]categoryRow) // old budget key -> renamed row
+	updates := make(map[string][][]interface{})
+	for _, cat := range targets {
+		oldKey := budgetKey(cat.CategoryName, cat.SubCategoryName)
+		if req.NewCategoryName != "" {
+			cat.CategoryName = req.NewCategoryName
 		}
-		err = u.sheetRepo.UpdateRange(spreadsheetID, sheetName+"!A4:C", values)
-		if err != nil {
-			return fmt.Errorf("failed to save new categories: %w", err)
+		if renameSub {
+			cat.SubCategoryName = req.NewSubCategoryName
+		}
+		if req.Budget != nil {
+			cat.Budget = *req.Budget
+		}
+		rangeStr := fmt.Sprintf("%s!A%d:C%d", sheetName, cat.Row, cat.Row)
+		updates[rangeStr] = [][]interface{}{{cat.CategoryName, cat.SubCategoryName, cat.Budget}}
+		if budgetKey(cat.CategoryName, cat.SubCategoryName) != oldKey {
+			renamed[oldKey] = cat
+		}
+	}
+
+	if len(renamed) > 0 {
+		if err := u.renameBudgetSettings(spreadsheetID, renamed, updates); err != nil {
+			return 0, err
 		}
 	}
 
+	renamedRows := 0
+	if renameSub {
+		if renamedRows, err = u.renameExpenseRows(spreadsheetID, targets[0].SubCategoryName, req.NewSubCategoryName, updates); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := u.sheetRepo.BatchUpdateRanges(spreadsheetID, updates); err != nil {
+		return 0, fmt.Errorf("failed to update category: %w", err)
+	}
+	return renamedRows, nil
+}
+
+// renameExpenseRows adds to updates the expense rows (column B) of every month tab whose
+// category is name, and returns how many there are
+func (u *CategoryUsecase) renameExpenseRows(spreadsheetID string, name string, newName string, updates map[string][][]interface{}) (int, error) {
+	sheetNames, err := monthSheetNames(u.sheetRepo, spreadsheetID)
+	if err != nil {
+		return 0, err
+	}
+	if len(sheetNames) == 0 {
+		return 0, nil
+	}
+
+	var ranges []string
+	for _, sheetName := range sheetNames {
+		ranges = append(ranges, sheetName+"!B2:B")
+	}
+	valueRanges, err := u.sheetRepo.BatchGetValues(spreadsheetID, ranges)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get expense transactions: %w", err)
+	}
+
+	renamed := 0
+	for i, sheetName := range sheetNames {
+		if len(valueRanges) <= i || valueRanges[i] == nil {
+			continue
+		}
+		for j, row := range valueRanges[i].Values {
+			if strings.EqualFold(strings.TrimSpace(cellString(row, 0)), name) {
+				updates[fmt.Sprintf("%s!B%d", sheetName, j+2)] = [][]interface{}{{newName}}
+				renamed++
+			}
+		}
+	}
+	return renamed, nil
+}
+
+// renameBudgetSettings adds to updates the "Budget Rollover" and "Budget Templates" rows of
+// the renamed sub-categories, keyed by their old budget key
+func (u *CategoryUsecase) renameBudgetSettings(spreadsheetID string, renamed map[string]categoryRow, updates map[string][][]interface{}) error {
+	rollovers, err := loadBudgetRollovers(u.sheetRepo, spreadsheetID)
+	if err != nil {
+		return err
+	}
+	for key, cat := range renamed {
+		if r, ok := rollovers[key]; ok {
+			rangeStr := fmt.Sprintf("%s!A%d:B%d", budgetRolloverSheet, r.Row, r.Row)
+			updates[rangeStr] = [][]interface{}{{cat.CategoryName, cat.SubCategoryName}}
+		}
+	}
+
+	rows, err := u.sheetRepo.GetOptionalRangeValuesUnformatted(spreadsheetID, budgetTemplateSheet+"!A2:C")
+	if err != nil {
+		return fmt.Errorf("failed to get budget templates: %w", err)
+	}
+	for i, row := range rows {
+		if cat, ok := renamed[budgetKey(cellString(row, 1), cellString(row, 2))]; ok {
+			rangeStr := fmt.Sprintf("%s!B%d:C%d", budgetTemplateSheet, i+2, i+2)
+			updates[rangeStr] = [][]interface{}{{cat.CategoryName, cat.SubCategoryName}}
+		}
+	}
+	return nil
+}
+
+// DeleteCategoryItem removes a sub-category, or every sub-category of a category when no
+// sub-category is given. Existing transactions are left untouched.
+func (u *CategoryUsecase) DeleteCategoryItem(spreadsheetID string, sheetName string, categoryName string, subCategoryName string) error {
+	categories, err := loadCategoryRows(u.sheetRepo, spreadsheetID, sheetName)
+	if err != nil {
+		return err
+	}
+
+	targets := findCategoryRows(categories, categoryName, subCategoryName)
+	if len(targets) == 0 {
+		return fmt.Errorf("category '%s' not found", categoryLabel(categoryName, subCategoryName))
+	}
+
+	for _, cat := range targets {
+		rangeStr := fmt.Sprintf("%s!A%d:C%d", sheetName, cat.Row, cat.Row)
+		if err := u.sheetRepo.ClearRange(spreadsheetID, rangeStr); err != nil {
+			return fmt.Errorf("failed to delete category: %w", err)
+		}
+	}
 	return nil
 }
+
+func categoryLabel(categoryName string, subCategoryName string) string {
+	if subCategoryName == "" {
+		return categoryName
+	}
+	if categoryName == "" {
+		return subCategoryName
+	}
+	return categoryName + " / " + subCategoryName
+}
@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+
+	"byeboros-backend/internal/adapter/http/model/request"
+)
+
+func TestUpdateCategoryItemRenamesExpenseRowsOfEveryMonthTab(t *testing.T) {
+	repo, fake := newFakeSheetRepo(t, []string{masterDataSheet, "Januari", "Maret"}, map[string][][]interface{}{
+		masterDataSheet + "!A4:C": {
+			{"Kebutuhan", "Makan", 1500000},
+			{"Kebutuhan", "Transport", 500000},
+		},
+		"Januari!B2:B": {{"Makan"}, {"Transport"}, {"makan"}},
+		"Maret!B2:B":   {{"Transport"}, {"Makan"}},
+	})
+
+	renamed, err := NewCategoryUsecase(repo, "IDR").UpdateCategoryItem("sheet-id", masterDataSheet, request.UpdateCategoryItemRequest{
+		SubCategoryName:    "Makan",
+		NewSubCategoryName: "Makan & Minum",
+	})
+	if err != nil {
+		t.Fatalf("UpdateCategoryItem: %v", err)
+	}
+	if renamed != 3 {
+		t.Errorf("renamed = %d, want 3", renamed)
+	}
+
+	want := map[string][][]interface{}{
+		masterDataSheet + "!A4:C4": {{"Kebutuhan", "Makan & Minum", float64(1500000)}},
+		"Januari!B2":               {{"Makan & Minum"}},
+		"Januari!B4":               {{"Makan & Minum"}},
+		"Maret!B3":                 {{"Makan & Minum"}},
+	}
+	if !reflect.DeepEqual(fake.writes, want) {
+		t.Errorf("writes = %v, want %v", fake.writes, want)
+	}
+}
@@ -0,0 +1,169 @@
+package usecase
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"byeboros-backend/internal/adapter/http/model/request"
+	"byeboros-backend/internal/adapter/http/model/response"
+	"byeboros-backend/internal/adapter/repository"
+)
+
+// expenseCategoryResolver maps expense categories to the sub-category spelling of the master data
+type expenseCategoryResolver struct {
+	names    map[string]string // lower-cased -> configured spelling
+	allowNew bool
+}
+
+// newExpenseCategoryResolver loads the sub-categories of the master data category table
+// (Master Data!A4:C). Month tabs hold transactions from row 2, so their A4:C are expense
+// rows, not categories. A spreadsheet without any configured category accepts every category.
+func newExpenseCategoryResolver(sheetRepo *repository.SheetRepository, spreadsheetID string, allowNew bool) (*expenseCategoryResolver, error) {
+	categories, err := loadCategoryRows(sheetRepo, spreadsheetID, masterDataSheet)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &expenseCategoryResolver{names: make(map[string]string), allowNew: allowNew || len(categories) == 0}
+	for _, cat := range categories {
+		r.names[strings.ToLower(cat.SubCategoryName)] = cat.SubCategoryName
+	}
+	return r, nil
+}
+
+// resolve returns the configured spelling of a category, the category itself when new
+// categories are allowed, or ErrUnknownCategory
+func (r *expenseCategoryResolver) resolve(name string) (string, error) {
+	if known, ok := r.names[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return known, nil
+	}
+	if r.allowNew {
+		return name, nil
+	}
+
+	msg := fmt.Sprintf("expense category '%s' is not configured in the master data", name)
+	if suggestion := closestName(name, r.names); suggestion != "" {
+		msg += fmt.Sprintf(" (did you mean '%s'?)", suggestion)
+	}
+	return "", fmt.Errorf("%w: %s, set allow_new_category to add it anyway", ErrUnknownCategory, msg)
+}
+
+// resolveSplits resolves the category of every split, returning a new slice
+func (r *expenseCategoryResolver) resolveSplits(splits []request.ExpenseSplitRequest) ([]request.ExpenseSplitRequest, error) {
+	if len(splits) == 0 {
+		return splits, nil
+	}
+
+	resolved := make([]request.ExpenseSplitRequest, len(splits))
+	for i, split := range splits {
+		category, err := r.resolve(split.Category)
+		if err != nil {
+			return nil, err
+		}
+		split.Category = category
+		resolved[i] = split
+	}
+	return resolved, nil
+}
+
+// closestName returns the known name with the smallest edit distance to name, when close
+// enough to be a likely typo
+func closestName(name string, known map[string]string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	best, bestDist := "", len(name)/3+1
+	for lower, spelling := range known {
+		if d := editDistance(name, lower); d < bestDist || (d == bestDist && spelling < best) {
+			best, bestDist = spelling, d
+		}
+	}
+	return best
+}
+
+// editDistance is the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
+
+// GetUnknownCategoryTransactions lists the transactions of a month tab whose category is
+// missing from the master: expenses are checked against the master data category table
+// (A4:C) and income against the master data income categories (H4:H)
+func (u *CategoryUsecase) GetUnknownCategoryTransactions(spreadsheetID string, sheetName string) (*response.UnknownCategoryResponse, error) {
+	expenseCategories, err := loadCategoryRows(u.sheetRepo, spreadsheetID, masterDataSheet)
+	if err != nil {
+		return nil, err
+	}
+	incomeCategories, _, err := loadIncomeCategoryRows(u.sheetRepo, spreadsheetID)
+	if err != nil {
+		return nil, err
+	}
+
+	expenseNames := make(map[string]string)
+	for _, cat := range expenseCategories {
+		expenseNames[strings.ToLower(cat.SubCategoryName)] = cat.SubCategoryName
+	}
+	incomeNames := make(map[string]string)
+	for _, cat := range incomeCategories {
+		incomeNames[strings.ToLower(cat.Name)] = cat.Name
+	}
+
+	valueRanges, err := u.sheetRepo.BatchGetValues(spreadsheetID, []string{sheetName + "!A2:G", sheetName + "!I2:N"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch transactions: %w", err)
+	}
+
+	var records []transactionRecord
+	if len(valueRanges) > 0 && valueRanges[0] != nil && len(expenseNames) > 0 {
+		records = append(records, parseExpenseRecords(sheetName, valueRanges[0].Values)...)
+	}
+	if len(valueRanges) > 1 && valueRanges[1] != nil && len(incomeNames) > 0 {
+		records = append(records, parseIncomeRecords(sheetName, valueRanges[1].Values)...)
+	}
+
+	res := &response.UnknownCategoryResponse{
+		SheetName:    sheetName,
+		Transactions: make([]response.UnknownCategoryItem, 0),
+	}
+	for _, rec := range records {
+		known := expenseNames
+		if rec.Type == "income" {
+			known = incomeNames
+		}
+		if _, ok := known[strings.ToLower(strings.TrimSpace(rec.Category))]; ok {
+			continue
+		}
+
+		res.Transactions = append(res.Transactions, response.UnknownCategoryItem{
+			ID:              rec.ID,
+			Type:            rec.Type,
+			TransactionName: rec.Description,
+			Category:        rec.Category,
+			Suggestion:      closestName(rec.Category, known),
+			Amount:          rec.Amount,
+			AmountDisplay:   formatAmount(rec.Amount, rec.Type == "income", u.baseCurrency),
+			Time:            rec.Time.Format("2006-01-02 15:04"),
+		})
+	}
+
+	sort.SliceStable(res.Transactions, func(i, j int) bool {
+		return res.Transactions[i].Time > res.Transactions[j].Time
+	})
+	return res, nil
+}
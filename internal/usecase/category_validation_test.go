@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"", "makan", 5},
+		{"makan", "", 5},
+		{"makan", "makan", 0},
+		{"makan", "makna", 2},
+		{"kitten", "sitting", 3},
+		{"transport", "transprot", 2},
+		{"jajan", "jajanan", 2},
+		{"café", "cafe", 1}, // counted in runes, not bytes
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestExpenseCategoryResolverReadsMasterData(t *testing.T) {
+	repo, fake := newFakeSheetRepo(t, []string{masterDataSheet, "Maret"}, map[string][][]interface{}{
+		masterDataSheet + "!A4:C": {
+			{"Kebutuhan", "Makan", 1500000},
+			{"Kebutuhan", "Transport", 500000},
+		},
+		// Expense rows of the month tab: a typo in row 4 must not become a known category
+		"Maret!A4:C": {
+			{"Nasi padang", "Makn", "Need"},
+		},
+	})
+
+	resolver, err := newExpenseCategoryResolver(repo, "sheet-id", false)
+	if err != nil {
+		t.Fatalf("newExpenseCategoryResolver: %v", err)
+	}
+	if fake.read("Maret!A4:C") {
+		t.Error("resolver read the month tab's expense rows as categories")
+	}
+
+	if got, err := resolver.resolve("makan"); err != nil || got != "Makan" {
+		t.Errorf("resolve(makan) = %q, %v, want Makan", got, err)
+	}
+	_, err = resolver.resolve("Makn")
+	if !errors.Is(err, ErrUnknownCategory) {
+		t.Fatalf("resolve(Makn) error = %v, want ErrUnknownCategory", err)
+	}
+	if !strings.Contains(err.Error(), "did you mean 'Makan'") {
+		t.Errorf("resolve(Makn) error = %q, want a suggestion of Makan", err)
+	}
+}
+
+func TestGetUnknownCategoryTransactionsReadsMasterData(t *testing.T) {
+	repo, fake := newFakeSheetRepo(t, []string{masterDataSheet, "Maret"}, map[string][][]interface{}{
+		masterDataSheet + "!A4:C": {{"Kebutuhan", "Makan", 1500000}},
+		masterDataSheet + "!H4:H": {{"Gaji"}},
+		"Maret!A2:G": {
+			{"Nasi padang", "Makan", "Need", 25000, "", "2024-03-02 12:00", "budi"},
+			{"Sate", "Makan", "Need", 30000, "", "2024-03-03 19:00", "budi"},
+			{"Bakso", "Makn", "Need", 20000, "", "2024-03-04 12:00", "budi"},
+		},
+		"Maret!I2:N": {{"Gaji Maret", "Gaji", 9000000, "", "2024-03-01 08:00", "budi"}},
+	})
+
+	res, err := NewCategoryUsecase(repo, "IDR").GetUnknownCategoryTransactions("sheet-id", "Maret")
+	if err != nil {
+		t.Fatalf("GetUnknownCategoryTransactions: %v", err)
+	}
+	if fake.read("Maret!A4:C") {
+		t.Error("report read the month tab's expense rows as categories")
+	}
+	if len(res.Transactions) != 1 || res.Transactions[0].Category != "Makn" || res.Transactions[0].Suggestion != "Makan" {
+		t.Errorf("Transactions = %+v, want only the Makn row with suggestion Makan", res.Transactions)
+	}
+}
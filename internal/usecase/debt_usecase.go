@@ -0,0 +1,409 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"byeboros-backend/internal/adapter/http/model/request"
+	"byeboros-backend/internal/adapter/http/model/response"
+	"byeboros-backend/internal/adapter/repository"
+)
+
+const (
+	// debtSheet lists money we owe (debt / utang) and money owed to us (receivable / piutang)
+	debtSheet = "Debts"
+
+	// debtPaymentSheet links installment payments to expense or income transactions
+	debtPaymentSheet = "Debt Payments"
+)
+
+var debtHeaders = []interface{}{"ID", "Type", "Counterparty", "Principal", "Installments", "Installment Amount", "First Due Date", "Notes", "Created At"}
+
+var debtPaymentHeaders = []interface{}{"ID", "Debt ID", "Amount", "Sheet", "Transaction ID", "Paid At", "Created By"}
+
+// debt is one row of the "Debts" sheet. Installments are due monthly from FirstDueDate.
+type debt struct {
+	ID                string
+	Type              string // "debt" or "receivable"
+	Counterparty      string
+	Principal         float64
+	Installments      int
+	InstallmentAmount float64
+	FirstDueDate      time.Time
+	Notes             string
+}
+
+// debtPayment is one row of the "Debt Payments" sheet
+type debtPayment struct {
+	ID            string
+	DebtID        string
+	Amount        float64
+	SheetName     string
+	TransactionID string
+	PaidAt        time.Time
+}
+
+// paymentTransactionType returns the transaction type that settles a debt: paying off what
+// we owe is an expense, getting a receivable back is an income
+func (d debt) paymentTransactionType() string {
+	if d.Type == "receivable" {
+		return "income"
+	}
+	return "expense"
+}
+
+// installmentDue returns the due date of the n-th installment (1-based). A due day past the
+// end of a shorter month falls on its last day, so a loan due on the 31st is due on 28/29 February.
+func (d debt) installmentDue(n int) time.Time {
+	return shiftMonths(d.FirstDueDate, 1-n)
+}
+
+// installmentAmount returns the amount of the n-th installment; the last one absorbs rounding
+func (d debt) installmentAmount(n int) float64 {
+	if n < d.Installments {
+		return d.InstallmentAmount
+	}
+	return math.Max(d.Principal-d.InstallmentAmount*float64(d.Installments-1), 0)
+}
+
+// DebtUsecase handles debts, receivables and their installment payments
+type DebtUsecase struct {
+	sheetRepo    *repository.SheetRepository
+	baseCurrency string // currency stored amounts (columns D and K) are expressed in
+}
+
+// NewDebtUsecase creates a new DebtUsecase
+func NewDebtUsecase(sheetRepo *repository.SheetRepository, baseCurrency string) *DebtUsecase {
+	return &DebtUsecase{sheetRepo: sheetRepo, baseCurrency: baseCurrency}
+}
+
+func (u *DebtUsecase) loadDebts(spreadsheetID string) ([]debt, error) {
+	rows, err := u.sheetRepo.GetOptionalRangeValuesUnformatted(spreadsheetID, debtSheet+"!A2:I")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get debts: %w", err)
+	}
+
+	var debts []debt
+	for _, row := range rows {
+		id := cellString(row, 0)
+		if id == "" {
+			continue
+		}
+		d := debt{
+			ID:                id,
+			Type:              cellString(row, 1),
+			Counterparty:      cellString(row, 2),
+			Principal:         cellNumber(row, 3),
+			Installments:      int(cellNumber(row, 4)),
+			InstallmentAmount: cellNumber(row, 5),
+			FirstDueDate:      parseCellTime(cellValue(row, 6)),
+			Notes:             cellString(row, 7),
+		}
+		if d.Installments < 1 {
+			d.Installments = 1
+		}
+		if d.InstallmentAmount <= 0 {
+			d.InstallmentAmount = d.Principal / float64(d.Installments)
+		}
+		debts = append(debts, d)
+	}
+	return debts, nil
+}
+
+func (u *DebtUsecase) loadPayments(spreadsheetID string) ([]debtPayment, error) {
+	rows, err := u.sheetRepo.GetOptionalRangeValuesUnformatted(spreadsheetID, debtPaymentSheet+"!A2:G")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get debt payments: %w", err)
+	}
+
+	var payments []debtPayment
+	for _, row := range rows {
+		id := cellString(row, 0)
+		if id == "" {
+			continue
+		}
+		payments = append(payments, debtPayment{
+			ID:            id,
+			DebtID:        cellString(row, 1),
+			Amount:        cellNumber(row, 2),
+			SheetName:     cellString(row, 3),
+			TransactionID: cellString(row, 4),
+			PaidAt:        parseCellTime(cellValue(row, 5)),
+		})
+	}
+	return payments, nil
+}
+
+// CreateDebt adds a debt or receivable with its installment schedule
+func (u *DebtUsecase) CreateDebt(spreadsheetID string, req request.CreateDebtRequest) (*response.DebtItem, error) {
+	firstDue, err := time.Parse("2006-01-02", req.FirstDueDate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid first_due_date: %w", err)
+	}
+
+	installments := req.Installments
+	if installments < 1 {
+		installments = 1
+	}
+	installmentAmount := req.InstallmentAmount
+	if installmentAmount <= 0 {
+		installmentAmount = math.Ceil(req.Principal / float64(installments))
+	}
+	if installmentAmount*float64(installments) < req.Principal-0.005 {
+		return nil, fmt.Errorf("%d installments of %s do not cover the principal %s",
+			installments, formatBalance(installmentAmount, u.baseCurrency), formatBalance(req.Principal, u.baseCurrency))
+	}
+
+	debtType := normalizeDebtType(req.Type)
+	if debtType == "" {
+		return nil, fmt.Errorf("invalid type: %s (must be 'debt' or 'receivable')", req.Type)
+	}
+
+	notes := ""
+	if req.Notes != nil {
+		notes = *req.Notes
+	}
+
+	d := debt{
+		ID:                newID("debt"),
+		Type:              debtType,
+		Counterparty:      req.Counterparty,
+		Principal:         req.Principal,
+		Installments:      installments,
+		InstallmentAmount: installmentAmount,
+		FirstDueDate:      firstDue,
+		Notes:             notes,
+	}
+
+	if err := u.sheetRepo.EnsureSheet(spreadsheetID, debtSheet, debtHeaders); err != nil {
+		return nil, err
+	}
+
+	values := []interface{}{
+		d.ID,                                     // Column A
+		d.Type,                                   // Column B
+		d.Counterparty,                           // Column C
+		d.Principal,                              // Column D
+		d.Installments,                           // Column E
+		d.InstallmentAmount,                      // Column F
+		req.FirstDueDate,                         // Column G
+		d.Notes,                                  // Column H
+		time.Now().Format("2006-01-02 15:04:05"), // Column I
+	}
+	if err := u.sheetRepo.AppendRow(spreadsheetID, debtSheet+"!A:I", values); err != nil {
+		return nil, fmt.Errorf("failed to add debt: %w", err)
+	}
+
+	item := buildDebtItem(d, nil, time.Now(), u.baseCurrency)
+	return &item, nil
+}
+
+// AddPayment records an installment payment linked to an existing expense (paying a debt) or
+// income (a receivable being repaid) transaction of the given month tab
+func (u *DebtUsecase) AddPayment(spreadsheetID string, sheetName string, debtID string, req request.DebtPaymentRequest, createdBy string) error {
+	debts, err := u.loadDebts(spreadsheetID)
+	if err != nil {
+		return err
+	}
+
+	var target *debt
+	for i := range debts {
+		if debts[i].ID == debtID {
+			target = &debts[i]
+			break
+		}
+	}
+	if target == nil {
+		return fmt.Errorf("debt %s not found", debtID)
+	}
+
+	txnType, _, err := parseTransactionID(req.TransactionID)
+	if err != nil {
+		return err
+	}
+	if txnType != target.paymentTransactionType() {
+		return fmt.Errorf("a %s must be paid with an %s transaction, got %s", target.Type, target.paymentTransactionType(), req.TransactionID)
+	}
+
+	record, err := loadTransactionRecord(u.sheetRepo, spreadsheetID, sheetName, req.TransactionID)
+	if err != nil {
+		return err
+	}
+
+	payments, err := u.loadPayments(spreadsheetID)
+	if err != nil {
+		return err
+	}
+
+	amount := record.Amount
+	if req.Amount > 0 {
+		amount = req.Amount
+	}
+
+	var linked, paid float64
+	for _, p := range payments {
+		if p.TransactionID == req.TransactionID && p.SheetName == sheetName {
+			linked += p.Amount
+		}
+		if p.DebtID == debtID {
+			paid += p.Amount
+		}
+	}
+	if linked+amount > record.Amount+0.005 {
+		return fmt.Errorf("payments linked to %s would exceed its amount (%s)", req.TransactionID, formatBalance(record.Amount, u.baseCurrency))
+	}
+	if paid+amount > target.Principal+0.005 {
+		return fmt.Errorf("payment exceeds the outstanding balance (%s)", formatBalance(target.Principal-paid, u.baseCurrency))
+	}
+
+	values := []interface{}{
+		newID("dp"),                      // Column A
+		debtID,                           // Column B
+		amount,                           // Column C
+		sheetName,                        // Column D
+		req.TransactionID,                // Column E
+		record.Time.Format("2006-01-02"), // Column F
+		createdBy,                        // Column G
+	}
+	if err := u.sheetRepo.EnsureSheet(spreadsheetID, debtPaymentSheet, debtPaymentHeaders); err != nil {
+		return err
+	}
+	if err := u.sheetRepo.AppendRow(spreadsheetID, debtPaymentSheet+"!A:G", values); err != nil {
+		return fmt.Errorf("failed to add debt payment: %w", err)
+	}
+	return nil
+}
+
+// GetDebts returns every debt and receivable with outstanding balances and next due installments
+func (u *DebtUsecase) GetDebts(spreadsheetID string) (*response.DebtResponse, error) {
+	debts, err := u.loadDebts(spreadsheetID)
+	if err != nil {
+		return nil, err
+	}
+
+	payments, err := u.loadPayments(spreadsheetID)
+	if err != nil {
+		return nil, err
+	}
+
+	byDebt := make(map[string][]debtPayment)
+	for _, p := range payments {
+		byDebt[p.DebtID] = append(byDebt[p.DebtID], p)
+	}
+
+	now := time.Now()
+	res := &response.DebtResponse{Debts: make([]response.DebtItem, 0, len(debts))}
+	for _, d := range debts {
+		item := buildDebtItem(d, byDebt[d.ID], now, u.baseCurrency)
+		if d.Type == "receivable" {
+			res.TotalReceivable += item.Outstanding
+		} else {
+			res.TotalDebt += item.Outstanding
+		}
+		if item.NextInstallment != nil {
+			res.UpcomingInstallments = append(res.UpcomingInstallments, response.DebtUpcomingInstallment{
+				DebtID:       d.ID,
+				Type:         d.Type,
+				Counterparty: d.Counterparty,
+				Installment:  *item.NextInstallment,
+			})
+		}
+		res.Debts = append(res.Debts, item)
+	}
+
+	sort.SliceStable(res.UpcomingInstallments, func(i, j int) bool {
+		return res.UpcomingInstallments[i].Installment.DueDate < res.UpcomingInstallments[j].Installment.DueDate
+	})
+
+	res.TotalDebtDisplay = formatBalance(res.TotalDebt, u.baseCurrency)
+	res.TotalReceivableDisplay = formatBalance(res.TotalReceivable, u.baseCurrency)
+	return res, nil
+}
+
+// buildDebtItem applies the payments to the installments in order and computes the balances
+func buildDebtItem(d debt, payments []debtPayment, now time.Time, baseCurrency string) response.DebtItem {
+	sort.SliceStable(payments, func(i, j int) bool {
+		return payments[i].PaidAt.Before(payments[j].PaidAt)
+	})
+
+	var paid float64
+	for _, p := range payments {
+		paid += p.Amount
+	}
+	outstanding := math.Max(d.Principal-paid, 0)
+
+	item := response.DebtItem{
+		ID:                 d.ID,
+		Type:               d.Type,
+		Counterparty:       d.Counterparty,
+		Principal:          d.Principal,
+		PrincipalDisplay:   formatBalance(d.Principal, baseCurrency),
+		Paid:               paid,
+		PaidDisplay:        formatBalance(paid, baseCurrency),
+		Outstanding:        outstanding,
+		OutstandingDisplay: formatBalance(outstanding, baseCurrency),
+		Notes:              d.Notes,
+		Installments:       make([]response.DebtInstallment, 0, d.Installments),
+		Payments:           make([]response.DebtPaymentItem, 0, len(payments)),
+	}
+
+	remainingPaid := paid
+	for n := 1; n <= d.Installments; n++ {
+		amount := d.installmentAmount(n)
+		covered := math.Min(remainingPaid, amount)
+		remainingPaid -= covered
+
+		due := d.installmentDue(n)
+		inst := response.DebtInstallment{
+			Number:        n,
+			DueDate:       due.Format("2006-01-02"),
+			Amount:        amount,
+			AmountDisplay: formatBalance(amount, baseCurrency),
+			Paid:          covered,
+			Status:        "pending",
+		}
+		switch {
+		case covered >= amount-0.005:
+			inst.Status = "paid"
+			item.InstallmentsPaid++
+		case due.Before(now):
+			inst.Status = "overdue"
+		}
+		if inst.Status != "paid" && item.NextInstallment == nil {
+			next := inst
+			item.NextInstallment = &next
+		}
+		item.Installments = append(item.Installments, inst)
+	}
+
+	for _, p := range payments {
+		item.Payments = append(item.Payments, response.DebtPaymentItem{
+			ID:            p.ID,
+			TransactionID: p.TransactionID,
+			SheetName:     p.SheetName,
+			Amount:        p.Amount,
+			AmountDisplay: formatBalance(p.Amount, baseCurrency),
+			PaidAt:        p.PaidAt.Format("2006-01-02"),
+		})
+	}
+
+	item.Status = "active"
+	if outstanding == 0 {
+		item.Status = "settled"
+	}
+	return item
+}
+
+// normalizeDebtType accepts the Indonesian terms as aliases
+func normalizeDebtType(t string) string {
+	switch strings.ToLower(strings.TrimSpace(t)) {
+	case "debt", "utang", "hutang":
+		return "debt"
+	case "receivable", "piutang":
+		return "receivable"
+	}
+	return ""
+}
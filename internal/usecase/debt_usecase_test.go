@@ -0,0 +1,138 @@
+package usecase
+
+import "testing"
+
+func TestInstallmentSchedule(t *testing.T) {
+	d := debt{Principal: 1000000, Installments: 3, InstallmentAmount: 333333, FirstDueDate: mustDate("2025-01-31")}
+
+	tests := []struct {
+		n          int
+		wantDue    string
+		wantAmount float64
+	}{
+		{1, "2025-01-31", 333333},
+		{2, "2025-02-28", 333333},
+		{3, "2025-03-31", 333334}, // the last installment absorbs rounding
+	}
+	for _, tt := range tests {
+		if got := d.installmentDue(tt.n).Format("2006-01-02"); got != tt.wantDue {
+			t.Errorf("installmentDue(%d) = %s, want %s", tt.n, got, tt.wantDue)
+		}
+		if got := d.installmentAmount(tt.n); got != tt.wantAmount {
+			t.Errorf("installmentAmount(%d) = %v, want %v", tt.n, got, tt.wantAmount)
+		}
+	}
+}
+
+func TestBuildDebtItem(t *testing.T) {
+	d := debt{
+		ID:                "debt_1",
+		Type:              "debt",
+		Counterparty:      "Budi",
+		Principal:         3000000,
+		Installments:      3,
+		InstallmentAmount: 1000000,
+		FirstDueDate:      mustDate("2025-01-15"),
+	}
+	now := mustDate("2025-03-20")
+	payment := func(amount float64, at string) debtPayment {
+		return debtPayment{Amount: amount, PaidAt: mustDate(at)}
+	}
+
+	tests := []struct {
+		name            string
+		payments        []debtPayment
+		wantOutstanding float64
+		wantStatus      string
+		wantPaidCount   int
+		wantStatuses    []string
+		wantCovered     []float64
+		wantNext        int // 0 when nothing is left to pay
+	}{
+		{
+			name:            "nothing paid",
+			wantOutstanding: 3000000,
+			wantStatus:      "active",
+			wantStatuses:    []string{"overdue", "overdue", "overdue"},
+			wantCovered:     []float64{0, 0, 0},
+			wantNext:        1,
+		},
+		{
+			name:            "partial payment spills into the next installment",
+			payments:        []debtPayment{payment(1500000, "2025-01-14")},
+			wantOutstanding: 1500000,
+			wantStatus:      "active",
+			wantPaidCount:   1,
+			wantStatuses:    []string{"paid", "overdue", "overdue"},
+			wantCovered:     []float64{1000000, 500000, 0},
+			wantNext:        2,
+		},
+		{
+			name:            "payments out of order",
+			payments:        []debtPayment{payment(1000000, "2025-02-15"), payment(1000000, "2025-01-15")},
+			wantOutstanding: 1000000,
+			wantStatus:      "active",
+			wantPaidCount:   2,
+			wantStatuses:    []string{"paid", "paid", "overdue"},
+			wantCovered:     []float64{1000000, 1000000, 0},
+			wantNext:        3,
+		},
+		{
+			name:            "overpaid is settled",
+			payments:        []debtPayment{payment(3500000, "2025-01-10")},
+			wantOutstanding: 0,
+			wantStatus:      "settled",
+			wantPaidCount:   3,
+			wantStatuses:    []string{"paid", "paid", "paid"},
+			wantCovered:     []float64{1000000, 1000000, 1000000},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := buildDebtItem(d, tt.payments, now, "IDR")
+
+			if item.Outstanding != tt.wantOutstanding {
+				t.Errorf("Outstanding = %v, want %v", item.Outstanding, tt.wantOutstanding)
+			}
+			if item.Status != tt.wantStatus {
+				t.Errorf("Status = %q, want %q", item.Status, tt.wantStatus)
+			}
+			if item.InstallmentsPaid != tt.wantPaidCount {
+				t.Errorf("InstallmentsPaid = %d, want %d", item.InstallmentsPaid, tt.wantPaidCount)
+			}
+			for i, inst := range item.Installments {
+				if inst.Status != tt.wantStatuses[i] || inst.Paid != tt.wantCovered[i] {
+					t.Errorf("installment %d = %s %v, want %s %v", inst.Number, inst.Status, inst.Paid, tt.wantStatuses[i], tt.wantCovered[i])
+				}
+			}
+			switch {
+			case tt.wantNext == 0 && item.NextInstallment != nil:
+				t.Errorf("NextInstallment = %d, want none", item.NextInstallment.Number)
+			case tt.wantNext != 0 && (item.NextInstallment == nil || item.NextInstallment.Number != tt.wantNext):
+				t.Errorf("NextInstallment = %+v, want number %d", item.NextInstallment, tt.wantNext)
+			}
+		})
+	}
+
+	// An installment that is not yet due stays pending
+	item := buildDebtItem(d, nil, mustDate("2025-02-01"), "IDR")
+	if got := item.Installments[1].Status; got != "pending" {
+		t.Errorf("installment 2 before its due date = %q, want pending", got)
+	}
+}
+
+func TestNormalizeDebtType(t *testing.T) {
+	tests := map[string]string{
+		"debt":       "debt",
+		" Utang ":    "debt",
+		"hutang":     "debt",
+		"receivable": "receivable",
+		"PIUTANG":    "receivable",
+		"loan":       "",
+	}
+	for in, want := range tests {
+		if got := normalizeDebtType(in); got != want {
+			t.Errorf("normalizeDebtType(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
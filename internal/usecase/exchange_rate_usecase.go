@@ -0,0 +1,231 @@
+package usecase
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"byeboros-backend/internal/adapter/http/model/request"
+	"byeboros-backend/internal/adapter/http/model/response"
+	"byeboros-backend/internal/adapter/repository"
+)
+
+// exchangeRateSheet keeps the locally maintained exchange-rate table.
+// Each rate converts one unit of Currency into the base currency from Effective Date on.
+const exchangeRateSheet = "Exchange Rates"
+
+var exchangeRateHeaders = []interface{}{"Currency", "Rate", "Effective Date", "Source", "Updated At"}
+
+// exchangeRate is one row of the "Exchange Rates" sheet
+type exchangeRate struct {
+	Currency      string
+	Rate          float64
+	EffectiveDate time.Time
+	Source        string
+}
+
+type exchangeRates []exchangeRate
+
+// rateFor returns the rate for a currency effective at the given time: the latest rate whose
+// effective date is not after it, or the earliest known rate for older transactions
+func (rates exchangeRates) rateFor(currency string, at time.Time) (float64, error) {
+	var candidates exchangeRates
+	for _, r := range rates {
+		if r.Currency == currency {
+			candidates = append(candidates, r)
+		}
+	}
+	if len(candidates) == 0 {
+		return 0, fmt.Errorf("no exchange rate for %s, add one via /api/exchange-rates", currency)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].EffectiveDate.Before(candidates[j].EffectiveDate)
+	})
+
+	rate := candidates[0].Rate
+	for _, r := range candidates {
+		if r.EffectiveDate.After(at) {
+			break
+		}
+		rate = r.Rate
+	}
+	return rate, nil
+}
+
+// ExchangeRateUsecase manages the exchange-rate table
+type ExchangeRateUsecase struct {
+	sheetRepo    *repository.SheetRepository
+	baseCurrency string // currency stored amounts (columns D and K) are expressed in
+}
+
+// NewExchangeRateUsecase creates a new ExchangeRateUsecase
+func NewExchangeRateUsecase(sheetRepo *repository.SheetRepository, baseCurrency string) *ExchangeRateUsecase {
+	return &ExchangeRateUsecase{sheetRepo: sheetRepo, baseCurrency: baseCurrency}
+}
+
+// loadExchangeRates reads the exchange-rate table; a missing sheet reads as empty
+func loadExchangeRates(sheetRepo *repository.SheetRepository, spreadsheetID string) (exchangeRates, error) {
+	rows, err := sheetRepo.GetOptionalRangeValuesUnformatted(spreadsheetID, exchangeRateSheet+"!A2:D")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exchange rates: %w", err)
+	}
+
+	var rates exchangeRates
+	for _, row := range rows {
+		currency := strings.ToUpper(cellString(row, 0))
+		if currency == "" {
+			continue
+		}
+		rate := cellNumber(row, 1)
+		if rate <= 0 {
+			continue
+		}
+		effective := parseCellTime(cellValue(row, 2))
+		rates = append(rates, exchangeRate{
+			Currency:      currency,
+			Rate:          rate,
+			EffectiveDate: effective,
+			Source:        cellString(row, 3),
+		})
+	}
+	return rates, nil
+}
+
+// currencyConversion resolves the currency of a new or updated transaction. It returns the
+// currency code to store (empty for the base currency) and its rate to the base currency.
+func currencyConversion(sheetRepo *repository.SheetRepository, spreadsheetID string, baseCurrency string, currency string, transactionAt string) (string, float64, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" || currency == baseCurrency {
+		return "", 1, nil
+	}
+
+	rates, err := loadExchangeRates(sheetRepo, spreadsheetID)
+	if err != nil {
+		return "", 0, err
+	}
+
+	// transaction_at is validated as d/MM/yyyy H:mm:ss by the controller
+	at, err := time.Parse("2/1/2006 15:4:5", transactionAt)
+	if err != nil {
+		at = time.Now()
+	}
+
+	rate, err := rates.rateFor(currency, at)
+	if err != nil {
+		return "", 0, err
+	}
+	return currency, rate, nil
+}
+
+// convertToBase converts an amount using a rate, rounded to 2 decimals
+func convertToBase(amount float64, rate float64) float64 {
+	return math.Round(amount*rate*100) / 100
+}
+
+// GetExchangeRates returns the exchange-rate table, newest effective date first
+func (u *ExchangeRateUsecase) GetExchangeRates(spreadsheetID string) (*response.ExchangeRateResponse, error) {
+	rates, err := loadExchangeRates(u.sheetRepo, spreadsheetID)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(rates, func(i, j int) bool {
+		if rates[i].Currency == rates[j].Currency {
+			return rates[i].EffectiveDate.After(rates[j].EffectiveDate)
+		}
+		return rates[i].Currency < rates[j].Currency
+	})
+
+	res := &response.ExchangeRateResponse{
+		BaseCurrency: u.baseCurrency,
+		Rates:        make([]response.ExchangeRateItem, 0, len(rates)),
+	}
+	for _, r := range rates {
+		res.Rates = append(res.Rates, response.ExchangeRateItem{
+			Currency:      r.Currency,
+			Rate:          r.Rate,
+			EffectiveDate: r.EffectiveDate.Format("2006-01-02"),
+			Source:        r.Source,
+		})
+	}
+	return res, nil
+}
+
+// SaveExchangeRate adds a manually entered rate
+func (u *ExchangeRateUsecase) SaveExchangeRate(spreadsheetID string, req request.ExchangeRateRequest) error {
+	return u.appendRates(spreadsheetID, []request.ExchangeRateRequest{req}, "manual")
+}
+
+// ImportExchangeRates adds rates from a CSV file with the columns currency,rate,effective_date.
+// A header row is skipped when present. It returns the number of imported rates.
+func (u *ExchangeRateUsecase) ImportExchangeRates(spreadsheetID string, file io.Reader) (int, error) {
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read CSV: %w", err)
+	}
+
+	var reqs []request.ExchangeRateRequest
+	for i, record := range records {
+		if len(record) < 3 {
+			return 0, fmt.Errorf("line %d: expected currency,rate,effective_date", i+1)
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(record[1]), 64)
+		if err != nil {
+			if i == 0 {
+				continue // header row
+			}
+			return 0, fmt.Errorf("line %d: invalid rate %q", i+1, record[1])
+		}
+
+		req := request.ExchangeRateRequest{
+			Currency:      record[0],
+			Rate:          rate,
+			EffectiveDate: strings.TrimSpace(record[2]),
+		}
+		if err := req.Validate(); err != nil {
+			return 0, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		reqs = append(reqs, req)
+	}
+
+	if len(reqs) == 0 {
+		return 0, fmt.Errorf("no exchange rates found in file")
+	}
+	if err := u.appendRates(spreadsheetID, reqs, "import"); err != nil {
+		return 0, err
+	}
+	return len(reqs), nil
+}
+
+func (u *ExchangeRateUsecase) appendRates(spreadsheetID string, reqs []request.ExchangeRateRequest, source string) error {
+	if err := u.sheetRepo.EnsureSheet(spreadsheetID, exchangeRateSheet, exchangeRateHeaders); err != nil {
+		return err
+	}
+
+	now := time.Now().Format("2006-01-02 15:04:05")
+	var rows [][]interface{}
+	for _, req := range reqs {
+		rows = append(rows, []interface{}{
+			strings.ToUpper(strings.TrimSpace(req.Currency)), // Column A
+			req.Rate,          // Column B
+			req.EffectiveDate, // Column C
+			source,            // Column D
+			now,               // Column E
+		})
+	}
+
+	if err := u.sheetRepo.BatchAppendRows(spreadsheetID, exchangeRateSheet+"!A:E", rows); err != nil {
+		return fmt.Errorf("failed to save exchange rates: %w", err)
+	}
+	return nil
+}
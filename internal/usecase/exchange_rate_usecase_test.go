@@ -0,0 +1,118 @@
+package usecase
+
+import "testing"
+
+func TestRateFor(t *testing.T) {
+	rates := exchangeRates{
+		{Currency: "USD", Rate: 16000, EffectiveDate: mustDate("2025-03-01")},
+		{Currency: "USD", Rate: 15500, EffectiveDate: mustDate("2025-01-01")},
+		{Currency: "SGD", Rate: 11800, EffectiveDate: mustDate("2025-01-01")},
+	}
+
+	tests := []struct {
+		name     string
+		currency string
+		at       string
+		want     float64
+		wantErr  bool
+	}{
+		{"latest effective rate", "USD", "2025-04-10", 16000, false},
+		{"rate effective on the day", "USD", "2025-03-01", 16000, false},
+		{"earlier rate before a change", "USD", "2025-02-28", 15500, false},
+		{"older than every rate uses the earliest", "USD", "2024-12-01", 15500, false},
+		{"other currency", "SGD", "2025-04-10", 11800, false},
+		{"unknown currency", "EUR", "2025-04-10", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rates.rateFor(tt.currency, mustDate(tt.at))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("rateFor = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertToBase(t *testing.T) {
+	tests := []struct {
+		amount float64
+		rate   float64
+		want   float64
+	}{
+		{12.5, 16000, 200000},
+		{1, 1, 1},
+		{3.33, 0.9137, 3.04},
+		{0.005, 1, 0.01},
+	}
+	for _, tt := range tests {
+		if got := convertToBase(tt.amount, tt.rate); got != tt.want {
+			t.Errorf("convertToBase(%v, %v) = %v, want %v", tt.amount, tt.rate, got, tt.want)
+		}
+	}
+}
+
+func TestFormatMoney(t *testing.T) {
+	tests := []struct {
+		amount   float64
+		currency string
+		want     string
+	}{
+		{1500000, "IDR", "Rp 1.500.000"},
+		{-1500000, "IDR", "Rp 1.500.000"},
+		{999, "IDR", "Rp 999"},
+		{0, "IDR", "Rp 0"},
+		{1250.5, "USD", "USD 1.250,50"},
+		{1250.999, "USD", "USD 1.251,00"},
+		{0.07, "USD", "USD 0,07"},
+		{1000000, "JPY", "JPY 1.000.000"},
+		{12.3, "SGD", "SGD 12,30"},
+	}
+	for _, tt := range tests {
+		if got := formatMoney(tt.amount, tt.currency); got != tt.want {
+			t.Errorf("formatMoney(%v, %q) = %q, want %q", tt.amount, tt.currency, got, tt.want)
+		}
+	}
+}
+
+func TestCurrencyConversion(t *testing.T) {
+	repo, fake := newFakeSheetRepo(t, []string{exchangeRateSheet}, map[string][][]interface{}{
+		exchangeRateSheet + "!A2:D": {
+			{"usd", 15500, "2025-01-01", "manual"},
+			{"USD", 16000, "2025-03-01", "manual"},
+			{"SGD", 0, "2025-01-01", "manual"}, // rates that are not positive are ignored
+		},
+	})
+
+	tests := []struct {
+		name         string
+		currency     string
+		at           string
+		wantCurrency string
+		wantRate     float64
+		wantErr      bool
+	}{
+		{"base currency is stored empty", "idr", "1/2/2025 10:00:00", "", 1, false},
+		{"no currency", "", "1/2/2025 10:00:00", "", 1, false},
+		{"rate at the transaction date", " usd ", "15/2/2025 10:00:00", "USD", 15500, false},
+		{"rate after a change", "USD", "1/3/2025 0:00:00", "USD", 16000, false},
+		{"currency without a valid rate", "SGD", "1/2/2025 10:00:00", "", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			currency, rate, err := currencyConversion(repo, "sheet", "IDR", tt.currency, tt.at)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if currency != tt.wantCurrency || rate != tt.wantRate {
+				t.Errorf("got %q %v, want %q %v", currency, rate, tt.wantCurrency, tt.wantRate)
+			}
+		})
+	}
+
+	if !fake.read(exchangeRateSheet + "!A2:D") {
+		t.Error("exchange-rate table was not read")
+	}
+}
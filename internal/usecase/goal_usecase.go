@@ -0,0 +1,350 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"byeboros-backend/internal/adapter/http/model/request"
+	"byeboros-backend/internal/adapter/http/model/response"
+	"byeboros-backend/internal/adapter/repository"
+)
+
+const (
+	// goalSheet lists savings goals
+	goalSheet = "Goals"
+
+	// goalContributionSheet lists money allocated to goals from income or transfers
+	goalContributionSheet = "Goal Contributions"
+
+	// goalPaceWindowDays is how far back contributions count towards the current saving pace
+	goalPaceWindowDays = 90
+)
+
+var goalHeaders = []interface{}{"ID", "Name", "Target Amount", "Deadline", "Created At"}
+
+var goalContributionHeaders = []interface{}{"ID", "Goal ID", "Amount", "Source Type", "Source ID", "Sheet", "Contributed At", "Notes"}
+
+// goal is one row of the "Goals" sheet
+type goal struct {
+	ID           string
+	Name         string
+	TargetAmount float64
+	Deadline     time.Time
+	CreatedAt    time.Time
+}
+
+// goalContribution is one row of the "Goal Contributions" sheet
+type goalContribution struct {
+	ID            string
+	GoalID        string
+	Amount        float64
+	SourceType    string // "income" or "transfer"
+	SourceID      string
+	SheetName     string
+	ContributedAt time.Time
+	Notes         string
+}
+
+// GoalUsecase handles savings goals and their progress
+type GoalUsecase struct {
+	sheetRepo    *repository.SheetRepository
+	baseCurrency string // currency stored amounts (columns D and K) are expressed in
+}
+
+// NewGoalUsecase creates a new GoalUsecase
+func NewGoalUsecase(sheetRepo *repository.SheetRepository, baseCurrency string) *GoalUsecase {
+	return &GoalUsecase{sheetRepo: sheetRepo, baseCurrency: baseCurrency}
+}
+
+func (u *GoalUsecase) loadGoals(spreadsheetID string) ([]goal, error) {
+	rows, err := u.sheetRepo.GetOptionalRangeValuesUnformatted(spreadsheetID, goalSheet+"!A2:E")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get goals: %w", err)
+	}
+
+	var goals []goal
+	for _, row := range rows {
+		id := cellString(row, 0)
+		if id == "" {
+			continue
+		}
+		goals = append(goals, goal{
+			ID:           id,
+			Name:         cellString(row, 1),
+			TargetAmount: cellNumber(row, 2),
+			Deadline:     parseCellTime(cellValue(row, 3)),
+			CreatedAt:    parseCellTime(cellValue(row, 4)),
+		})
+	}
+	return goals, nil
+}
+
+func (u *GoalUsecase) loadContributions(spreadsheetID string) ([]goalContribution, error) {
+	rows, err := u.sheetRepo.GetOptionalRangeValuesUnformatted(spreadsheetID, goalContributionSheet+"!A2:H")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get goal contributions: %w", err)
+	}
+
+	var contributions []goalContribution
+	for _, row := range rows {
+		id := cellString(row, 0)
+		if id == "" {
+			continue
+		}
+		contributions = append(contributions, goalContribution{
+			ID:            id,
+			GoalID:        cellString(row, 1),
+			Amount:        cellNumber(row, 2),
+			SourceType:    cellString(row, 3),
+			SourceID:      cellString(row, 4),
+			SheetName:     cellString(row, 5),
+			ContributedAt: parseCellTime(cellValue(row, 6)),
+			Notes:         cellString(row, 7),
+		})
+	}
+	return contributions, nil
+}
+
+// CreateGoal adds a savings goal with a target amount and deadline
+func (u *GoalUsecase) CreateGoal(spreadsheetID string, req request.CreateGoalRequest) (*response.GoalItem, error) {
+	deadline, err := time.Parse("2006-01-02", req.Deadline)
+	if err != nil {
+		return nil, fmt.Errorf("invalid deadline: %w", err)
+	}
+
+	goals, err := u.loadGoals(spreadsheetID)
+	if err != nil {
+		return nil, err
+	}
+	for _, g := range goals {
+		if strings.EqualFold(g.Name, req.Name) {
+			return nil, fmt.Errorf("goal '%s' already exists", req.Name)
+		}
+	}
+
+	now := time.Now()
+	g := goal{
+		ID:           newID("goal"),
+		Name:         req.Name,
+		TargetAmount: req.TargetAmount,
+		Deadline:     deadline,
+		CreatedAt:    now,
+	}
+
+	values := []interface{}{
+		g.ID,                              // Column A
+		g.Name,                            // Column B
+		g.TargetAmount,                    // Column C
+		req.Deadline,                      // Column D
+		now.Format("2006-01-02 15:04:05"), // Column E
+	}
+	if err := u.sheetRepo.EnsureSheet(spreadsheetID, goalSheet, goalHeaders); err != nil {
+		return nil, err
+	}
+	if err := u.sheetRepo.AppendRow(spreadsheetID, goalSheet+"!A:E", values); err != nil {
+		return nil, fmt.Errorf("failed to add goal: %w", err)
+	}
+
+	item := buildGoalItem(g, nil, now, u.baseCurrency)
+	return &item, nil
+}
+
+// AddContribution allocates money from an income transaction or a transfer to a goal.
+// The allocations from one source can never exceed the source amount.
+func (u *GoalUsecase) AddContribution(spreadsheetID string, sheetName string, goalID string, req request.GoalContributionRequest) error {
+	goals, err := u.loadGoals(spreadsheetID)
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, g := range goals {
+		if g.ID == goalID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("goal %s not found", goalID)
+	}
+
+	sourceAmount, sourceTime, err := u.contributionSource(spreadsheetID, sheetName, req.SourceType, req.SourceID)
+	if err != nil {
+		return err
+	}
+
+	contributions, err := u.loadContributions(spreadsheetID)
+	if err != nil {
+		return err
+	}
+	allocated := req.Amount
+	for _, c := range contributions {
+		if c.SourceType == req.SourceType && c.SourceID == req.SourceID && (req.SourceType == "transfer" || c.SheetName == sheetName) {
+			allocated += c.Amount
+		}
+	}
+	if allocated > sourceAmount+0.005 {
+		return fmt.Errorf("contributions from %s would exceed its amount (%s)", req.SourceID, formatBalance(sourceAmount, u.baseCurrency))
+	}
+
+	contributedAt := sourceTime.Format("2006-01-02")
+	if req.ContributedAt != "" {
+		contributedAt = req.ContributedAt
+	}
+
+	notes := ""
+	if req.Notes != nil {
+		notes = *req.Notes
+	}
+
+	values := []interface{}{
+		newID("gc"),    // Column A
+		goalID,         // Column B
+		req.Amount,     // Column C
+		req.SourceType, // Column D
+		req.SourceID,   // Column E
+		sheetName,      // Column F
+		contributedAt,  // Column G
+		notes,          // Column H
+	}
+	if err := u.sheetRepo.EnsureSheet(spreadsheetID, goalContributionSheet, goalContributionHeaders); err != nil {
+		return err
+	}
+	if err := u.sheetRepo.AppendRow(spreadsheetID, goalContributionSheet+"!A:H", values); err != nil {
+		return fmt.Errorf("failed to add goal contribution: %w", err)
+	}
+	return nil
+}
+
+// contributionSource returns the amount and time of the income row or transfer a contribution comes from
+func (u *GoalUsecase) contributionSource(spreadsheetID string, sheetName string, sourceType string, sourceID string) (float64, time.Time, error) {
+	switch sourceType {
+	case "income":
+		if !strings.HasPrefix(sourceID, "txn_inc_") {
+			return 0, time.Time{}, fmt.Errorf("source %s is not an income transaction", sourceID)
+		}
+		record, err := loadTransactionRecord(u.sheetRepo, spreadsheetID, sheetName, sourceID)
+		if err != nil {
+			return 0, time.Time{}, err
+		}
+		return record.Amount, record.Time, nil
+
+	case "transfer":
+		transfers, err := loadTransfers(u.sheetRepo, spreadsheetID)
+		if err != nil {
+			return 0, time.Time{}, err
+		}
+		for _, t := range transfers {
+			if t.ID == sourceID {
+				return t.Amount, t.Time, nil
+			}
+		}
+		return 0, time.Time{}, fmt.Errorf("transfer %s not found", sourceID)
+	}
+
+	return 0, time.Time{}, fmt.Errorf("invalid source_type: %s (must be 'income' or 'transfer')", sourceType)
+}
+
+// GetGoals returns every goal with its progress, required monthly contribution and projection
+func (u *GoalUsecase) GetGoals(spreadsheetID string) (*response.GoalResponse, error) {
+	goals, err := u.loadGoals(spreadsheetID)
+	if err != nil {
+		return nil, err
+	}
+
+	contributions, err := u.loadContributions(spreadsheetID)
+	if err != nil {
+		return nil, err
+	}
+
+	byGoal := make(map[string][]goalContribution)
+	for _, c := range contributions {
+		byGoal[c.GoalID] = append(byGoal[c.GoalID], c)
+	}
+
+	now := time.Now()
+	res := &response.GoalResponse{Goals: make([]response.GoalItem, 0, len(goals))}
+	for _, g := range goals {
+		res.Goals = append(res.Goals, buildGoalItem(g, byGoal[g.ID], now, u.baseCurrency))
+	}
+
+	sort.SliceStable(res.Goals, func(i, j int) bool {
+		return res.Goals[i].Deadline < res.Goals[j].Deadline
+	})
+	return res, nil
+}
+
+// buildGoalItem computes goal progress. The pace is the average monthly contribution over the
+// last goalPaceWindowDays (or since the goal was created, when more recent).
+func buildGoalItem(g goal, contributions []goalContribution, now time.Time, baseCurrency string) response.GoalItem {
+	var saved, recent float64
+	for _, c := range contributions {
+		saved += c.Amount
+		if now.Sub(c.ContributedAt) <= goalPaceWindowDays*24*time.Hour {
+			recent += c.Amount
+		}
+	}
+
+	remaining := math.Max(g.TargetAmount-saved, 0)
+	percent := 0.0
+	if g.TargetAmount > 0 {
+		percent = math.Min(saved/g.TargetAmount*100, 100)
+	}
+
+	monthsLeft := monthsBetween(now, g.Deadline)
+	requiredMonthly := remaining
+	if monthsLeft > 1 {
+		requiredMonthly = remaining / monthsLeft
+	}
+
+	windowStart := now.AddDate(0, 0, -goalPaceWindowDays)
+	if !g.CreatedAt.IsZero() && g.CreatedAt.After(windowStart) {
+		windowStart = g.CreatedAt
+	}
+	windowMonths := math.Max(monthsBetween(windowStart, now), 1)
+	pace := recent / windowMonths
+
+	item := response.GoalItem{
+		ID:                     g.ID,
+		Name:                   g.Name,
+		TargetAmount:           g.TargetAmount,
+		TargetAmountDisplay:    formatBalance(g.TargetAmount, baseCurrency),
+		Deadline:               g.Deadline.Format("2006-01-02"),
+		SavedAmount:            saved,
+		SavedAmountDisplay:     formatBalance(saved, baseCurrency),
+		RemainingAmount:        remaining,
+		RemainingAmountDisplay: formatBalance(remaining, baseCurrency),
+		ProgressPercent:        math.Round(percent*10) / 10,
+		RequiredMonthly:        math.Ceil(requiredMonthly),
+		RequiredMonthlyDisplay: formatBalance(math.Ceil(requiredMonthly), baseCurrency),
+		MonthlyPace:            math.Round(pace),
+		MonthlyPaceDisplay:     formatBalance(math.Round(pace), baseCurrency),
+	}
+
+	switch {
+	case remaining == 0:
+		item.Status = "completed"
+		item.ProjectedCompletion = now.Format("2006-01-02")
+	case pace <= 0:
+		item.Status = "no_progress"
+	default:
+		days := int(math.Ceil(remaining / pace * 30.44))
+		projected := now.AddDate(0, 0, days)
+		item.ProjectedCompletion = projected.Format("2006-01-02")
+		item.Status = "on_track"
+		if projected.After(g.Deadline) {
+			item.Status = "behind"
+		}
+	}
+	return item
+}
+
+// monthsBetween returns the fractional number of months from a to b (0 when b is before a)
+func monthsBetween(a, b time.Time) float64 {
+	if !b.After(a) {
+		return 0
+	}
+	return b.Sub(a).Hours() / 24 / 30.44
+}
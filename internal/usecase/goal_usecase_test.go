@@ -0,0 +1,142 @@
+package usecase
+
+import "testing"
+
+func TestBuildGoalItem(t *testing.T) {
+	now := mustDate("2025-06-01")
+	contribution := func(amount float64, at string) goalContribution {
+		return goalContribution{Amount: amount, ContributedAt: mustDate(at)}
+	}
+
+	tests := []struct {
+		name          string
+		createdAt     string
+		deadline      string
+		contributions []goalContribution
+		wantSaved     float64
+		wantPercent   float64
+		wantRequired  float64
+		wantPace      float64
+		wantProjected string
+		wantStatus    string
+	}{
+		{
+			// Created a month ago, so the pace window is one month
+			name:          "on track",
+			createdAt:     "2025-05-02",
+			deadline:      "2026-06-01",
+			contributions: []goalContribution{contribution(2000000, "2025-05-10")},
+			wantSaved:     2000000,
+			wantPercent:   20,
+			wantRequired:  667179, // 8000000 over 365 days
+			wantPace:      2000000,
+			wantProjected: "2025-10-01", // 4 months of 30.44 days
+			wantStatus:    "on_track",
+		},
+		{
+			name:          "behind",
+			createdAt:     "2025-05-02",
+			deadline:      "2025-08-01",
+			contributions: []goalContribution{contribution(2000000, "2025-05-10")},
+			wantSaved:     2000000,
+			wantPercent:   20,
+			wantRequired:  3992132, // 8000000 over 61 days
+			wantPace:      2000000,
+			wantProjected: "2025-10-01",
+			wantStatus:    "behind",
+		},
+		{
+			name:          "pace averages over the window",
+			createdAt:     "2024-06-01",
+			deadline:      "2026-06-01",
+			contributions: []goalContribution{contribution(1000000, "2025-01-15"), contribution(3044000, "2025-05-01")},
+			wantSaved:     4044000,
+			wantPercent:   40.4,
+			wantRequired:  496715,  // 5956000 over 365 days
+			wantPace:      1029548, // 3044000 over 90 days
+			wantProjected: "2025-11-25",
+			wantStatus:    "on_track",
+		},
+		{
+			name:          "completed",
+			createdAt:     "2025-01-01",
+			deadline:      "2025-12-01",
+			contributions: []goalContribution{contribution(6000000, "2025-02-01"), contribution(4500000, "2025-05-01")},
+			wantSaved:     10500000,
+			wantPercent:   100,
+			wantRequired:  0,
+			wantPace:      1522000, // 4500000 over the 90-day window
+			wantProjected: "2025-06-01",
+			wantStatus:    "completed",
+		},
+		{
+			name:          "no recent contributions",
+			createdAt:     "2024-12-01",
+			deadline:      "2025-12-01",
+			contributions: []goalContribution{contribution(1000000, "2025-01-01")},
+			wantSaved:     1000000,
+			wantPercent:   10,
+			wantRequired:  1497050, // 9000000 over 183 days
+			wantPace:      0,
+			wantStatus:    "no_progress",
+		},
+		{
+			name:          "deadline passed needs the rest at once",
+			createdAt:     "2024-12-01",
+			deadline:      "2025-05-01",
+			contributions: []goalContribution{contribution(2000000, "2025-01-01")},
+			wantSaved:     2000000,
+			wantPercent:   20,
+			wantRequired:  8000000,
+			wantPace:      0,
+			wantStatus:    "no_progress",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := goal{
+				ID:           "goal_1",
+				Name:         "Dana darurat",
+				TargetAmount: 10000000,
+				CreatedAt:    mustDate(tt.createdAt),
+				Deadline:     mustDate(tt.deadline),
+			}
+			item := buildGoalItem(g, tt.contributions, now, "IDR")
+
+			if item.SavedAmount != tt.wantSaved {
+				t.Errorf("SavedAmount = %v, want %v", item.SavedAmount, tt.wantSaved)
+			}
+			if item.ProgressPercent != tt.wantPercent {
+				t.Errorf("ProgressPercent = %v, want %v", item.ProgressPercent, tt.wantPercent)
+			}
+			if item.RequiredMonthly != tt.wantRequired {
+				t.Errorf("RequiredMonthly = %v, want %v", item.RequiredMonthly, tt.wantRequired)
+			}
+			if item.MonthlyPace != tt.wantPace {
+				t.Errorf("MonthlyPace = %v, want %v", item.MonthlyPace, tt.wantPace)
+			}
+			if item.ProjectedCompletion != tt.wantProjected {
+				t.Errorf("ProjectedCompletion = %q, want %q", item.ProjectedCompletion, tt.wantProjected)
+			}
+			if item.Status != tt.wantStatus {
+				t.Errorf("Status = %q, want %q", item.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestMonthsBetween(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want float64
+	}{
+		{"2025-01-01", "2025-01-01", 0},
+		{"2025-03-01", "2025-01-01", 0},
+		{"2025-01-01", "2025-12-31", 364 / 30.44},
+	}
+	for _, tt := range tests {
+		if got := monthsBetween(mustDate(tt.a), mustDate(tt.b)); got != tt.want {
+			t.Errorf("monthsBetween(%s, %s) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
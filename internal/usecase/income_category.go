@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"byeboros-backend/internal/adapter/repository"
+)
+
+// masterDataSheet holds the household-wide master lists; income categories live in H4:H
+const masterDataSheet = "Master Data"
+
+// ErrUnknownCategory is returned when a transaction references a category missing from the master
+var ErrUnknownCategory = errors.New("unknown category")
+
+// incomeCategoryRow is one income category of the master data tab
+type incomeCategoryRow struct {
+	Row  int // 1-based sheet row
+	Name string
+}
+
+// loadIncomeCategoryRows reads Master Data!H4:H with the sheet row of each category.
+// It also returns the first row that can hold a new category (a cleared row or the end).
+func loadIncomeCategoryRows(sheetRepo *repository.SheetRepository, spreadsheetID string) ([]incomeCategoryRow, int, error) {
+	rows, err := sheetRepo.GetRangeValues(spreadsheetID, masterDataSheet+"!H4:H")
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get income categories from master data: %w", err)
+	}
+
+	var categories []incomeCategoryRow
+	freeRow := 0
+	for i, row := range rows {
+		name := strings.TrimSpace(cellString(row, 0))
+		if name == "" {
+			if freeRow == 0 {
+				freeRow = i + 4
+			}
+			continue
+		}
+		categories = append(categories, incomeCategoryRow{Row: i + 4, Name: name})
+	}
+	if freeRow == 0 {
+		freeRow = len(rows) + 4
+	}
+	return categories, freeRow, nil
+}
+
+func findIncomeCategory(categories []incomeCategoryRow, name string) *incomeCategoryRow {
+	name = strings.TrimSpace(name)
+	for i := range categories {
+		if strings.EqualFold(categories[i].Name, name) {
+			return &categories[i]
+		}
+	}
+	return nil
+}
+
+// resolveIncomeCategory returns the master spelling of an income category. Spreadsheets
+// without any income category in the master data accept every category.
+func resolveIncomeCategory(sheetRepo *repository.SheetRepository, spreadsheetID string, name string) (string, error) {
+	categories, _, err := loadIncomeCategoryRows(sheetRepo, spreadsheetID)
+	if err != nil {
+		return "", err
+	}
+	if len(categories) == 0 {
+		return name, nil
+	}
+
+	if cat := findIncomeCategory(categories, name); cat != nil {
+		return cat.Name, nil
+	}
+	return "", fmt.Errorf("%w: income category '%s' does not exist, add it via /api/category/income", ErrUnknownCategory, name)
+}
+
+// AddIncomeCategory adds an income category to the master data tab
+func (u *CategoryUsecase) AddIncomeCategory(spreadsheetID string, name string) error {
+	categories, freeRow, err := loadIncomeCategoryRows(u.sheetRepo, spreadsheetID)
+	if err != nil {
+		return err
+	}
+	if existing := findIncomeCategory(categories, name); existing != nil {
+		return fmt.Errorf("income category '%s' already exists", existing.Name)
+	}
+
+	rangeStr := fmt.Sprintf("%s!H%d", masterDataSheet, freeRow)
+	if err := u.sheetRepo.UpdateRange(spreadsheetID, rangeStr, [][]interface{}{{strings.TrimSpace(name)}}); err != nil {
+		return fmt.Errorf("failed to add income category: %w", err)
+	}
+	return nil
+}
+
+// RenameIncomeCategory renames an income category in the master data tab and on the income
+// rows (column J) of every month tab. It returns the number of renamed transactions.
+func (u *CategoryUsecase) RenameIncomeCategory(spreadsheetID string, name string, newName string) (int, error) {
+	categories, _, err := loadIncomeCategoryRows(u.sheetRepo, spreadsheetID)
+	if err != nil {
+		return 0, err
+	}
+
+	target := findIncomeCategory(categories, name)
+	if target == nil {
+		return 0, fmt.Errorf("income category '%s' not found", name)
+	}
+	if other := findIncomeCategory(categories, newName); other != nil && other.Row != target.Row {
+		return 0, fmt.Errorf("income category '%s' already exists", other.Name)
+	}
+
+	newName = strings.TrimSpace(newName)
+	updates := map[string][][]interface{}{
+		fmt.Sprintf("%s!H%d", masterDataSheet, target.Row): {{newName}},
+	}
+
+	sheetNames, err := monthSheetNames(u.sheetRepo, spreadsheetID)
+	if err != nil {
+		return 0, err
+	}
+
+	renamed := 0
+	if len(sheetNames) > 0 {
+		var ranges []string
+		for _, sheetName := range sheetNames {
+			ranges = append(ranges, sheetName+"!J2:J")
+		}
+		valueRanges, err := u.sheetRepo.BatchGetValues(spreadsheetID, ranges)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get income transactions: %w", err)
+		}
+		for i, sheetName := range sheetNames {
+			if len(valueRanges) <= i || valueRanges[i] == nil {
+				continue
+			}
+			for j, row := range valueRanges[i].Values {
+				if strings.EqualFold(strings.TrimSpace(cellString(row, 0)), target.Name) {
+					updates[fmt.Sprintf("%s!J%d", sheetName, j+2)] = [][]interface{}{{newName}}
+					renamed++
+				}
+			}
+		}
+	}
+
+	if err := u.sheetRepo.BatchUpdateRanges(spreadsheetID, updates); err != nil {
+		return 0, fmt.Errorf("failed to rename income category: %w", err)
+	}
+	return renamed, nil
+}
+
+// DeleteIncomeCategory removes an income category from the master data tab.
+// Existing transactions keep their category.
+func (u *CategoryUsecase) DeleteIncomeCategory(spreadsheetID string, name string) error {
+	categories, _, err := loadIncomeCategoryRows(u.sheetRepo, spreadsheetID)
+	if err != nil {
+		return err
+	}
+
+	target := findIncomeCategory(categories, name)
+	if target == nil {
+		return fmt.Errorf("income category '%s' not found", name)
+	}
+
+	rangeStr := fmt.Sprintf("%s!H%d", masterDataSheet, target.Row)
+	if err := u.sheetRepo.ClearRange(spreadsheetID, rangeStr); err != nil {
+		return fmt.Errorf("failed to delete income category: %w", err)
+	}
+	return nil
+}
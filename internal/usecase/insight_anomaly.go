@@ -0,0 +1,230 @@
+package usecase
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"byeboros-backend/internal/adapter/http/model/response"
+)
+
+const (
+	// anomalyHistoryMonths is how far before the reported range the history reaches
+	anomalyHistoryMonths = 12
+
+	// anomalyMinSamples is the number of earlier expenses a sub-category needs before its
+	// amounts or descriptions are judged
+	anomalyMinSamples = 5
+
+	// anomalyAmountScore flags amounts whose robust z-score (median/MAD) exceeds it
+	anomalyAmountScore = 3.5
+
+	// anomalyRollingDays is the window of the rolling daily average
+	anomalyRollingDays = 30
+
+	// anomalyMinRollingDays is how many days of the window must be known to judge a day
+	anomalyMinRollingDays = 14
+)
+
+// median returns the median of values, sorting them in place
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sort.Float64s(values)
+	mid := len(values) / 2
+	if len(values)%2 == 0 {
+		return (values[mid-1] + values[mid]) / 2
+	}
+	return values[mid]
+}
+
+// anomalySeverity grades a finding by how far its score is past the threshold
+func anomalySeverity(score, threshold float64) string {
+	if score >= 2*threshold {
+		return "high"
+	}
+	return "medium"
+}
+
+// GetAnomalies flags unusual expenses within a date range, judged against the stored history
+// of the year before it:
+//   - amounts far above what the sub-category usually costs (median and MAD of earlier expenses)
+//   - descriptions never seen before in a sub-category, when the amount is above its median
+//   - days whose total spending spikes above the rolling 30-day average
+func (u *TransactionUsecase) GetAnomalies(spreadsheetID string, fromStr string, toStr string) (*response.AnomalyResponse, error) {
+	from, to, err := ParseDateRange(fromStr, toStr)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := loadRecordsInRange(u.sheetRepo, spreadsheetID, from.AddDate(0, -anomalyHistoryMonths, 0), to)
+	if err != nil {
+		return nil, err
+	}
+
+	var expenses []transactionRecord
+	for _, rec := range records {
+		if rec.Type == "expense" && rec.Amount > 0 {
+			expenses = append(expenses, rec)
+		}
+	}
+	sort.SliceStable(expenses, func(i, j int) bool {
+		return expenses[i].Time.Before(expenses[j].Time)
+	})
+
+	res := &response.AnomalyResponse{
+		From:      from.Format("2006-01-02"),
+		To:        to.Format("2006-01-02"),
+		Anomalies: make([]response.AnomalyItem, 0),
+	}
+	res.Anomalies = append(res.Anomalies, transactionAnomalies(expenses, from, u.baseCurrency)...)
+	res.Anomalies = append(res.Anomalies, dailySpikeAnomalies(expenses, from, to, u.baseCurrency)...)
+
+	sort.SliceStable(res.Anomalies, func(i, j int) bool {
+		if res.Anomalies[i].Date == res.Anomalies[j].Date {
+			return res.Anomalies[i].Score > res.Anomalies[j].Score
+		}
+		return res.Anomalies[i].Date > res.Anomalies[j].Date
+	})
+	return res, nil
+}
+
+// transactionAnomalies judges each expense from `from` on against the earlier expenses of
+// its sub-category. expenses must be sorted by time.
+func transactionAnomalies(expenses []transactionRecord, from time.Time, baseCurrency string) []response.AnomalyItem {
+	type categoryHistory struct {
+		amounts      []float64
+		descriptions map[string]bool
+	}
+	histories := make(map[string]*categoryHistory)
+
+	var anomalies []response.AnomalyItem
+	for _, rec := range expenses {
+		key := strings.ToLower(strings.TrimSpace(rec.Category))
+		h, ok := histories[key]
+		if !ok {
+			h = &categoryHistory{descriptions: make(map[string]bool)}
+			histories[key] = h
+		}
+		desc := strings.ToLower(strings.TrimSpace(rec.Description))
+
+		if !rec.Time.Before(from) && len(h.amounts) >= anomalyMinSamples {
+			amounts := append([]float64(nil), h.amounts...)
+			med := median(amounts)
+			deviations := make([]float64, len(amounts))
+			for i, a := range amounts {
+				deviations[i] = math.Abs(a - med)
+			}
+			mad := median(deviations)
+
+			// Robust z-score; without spread, compare with the median instead
+			var score float64
+			if mad > 0 {
+				score = 0.6745 * (rec.Amount - med) / mad
+			} else if med > 0 {
+				score = (rec.Amount/med - 1) * anomalyAmountScore / 2
+			}
+
+			item := response.AnomalyItem{
+				Date:            rec.Time.Format("2006-01-02"),
+				TransactionID:   rec.ID,
+				SheetName:       rec.SheetName,
+				Description:     rec.Description,
+				Category:        rec.Category,
+				Amount:          rec.Amount,
+				AmountDisplay:   formatBalance(rec.Amount, baseCurrency),
+				Expected:        med,
+				ExpectedDisplay: formatBalance(med, baseCurrency),
+			}
+
+			switch {
+			case score > anomalyAmountScore && rec.Amount > 2*med:
+				item.Type = "amount"
+				item.Score = math.Round(score*10) / 10
+				item.Severity = anomalySeverity(score, anomalyAmountScore)
+				item.Explanation = fmt.Sprintf("%s is %.1fx the usual %s for %s (median of %d earlier expenses)",
+					formatBalance(rec.Amount, baseCurrency), rec.Amount/med, formatBalance(med, baseCurrency), rec.Category, len(amounts))
+				anomalies = append(anomalies, item)
+			case desc != "" && !h.descriptions[desc] && rec.Amount > med:
+				item.Type = "description"
+				item.Score = math.Round(rec.Amount/med*10) / 10
+				item.Severity = "low"
+				item.Explanation = fmt.Sprintf("First time '%s' appears in %s (%d other descriptions seen), at %s against a usual %s",
+					rec.Description, rec.Category, len(h.descriptions), formatBalance(rec.Amount, baseCurrency), formatBalance(med, baseCurrency))
+				anomalies = append(anomalies, item)
+			}
+		}
+
+		h.amounts = append(h.amounts, rec.Amount)
+		if desc != "" {
+			h.descriptions[desc] = true
+		}
+	}
+	return anomalies
+}
+
+// dailySpikeAnomalies flags days in [from, to] whose total spending is well above the average
+// of the anomalyRollingDays days before. expenses must be sorted by time.
+func dailySpikeAnomalies(expenses []transactionRecord, from, to time.Time, baseCurrency string) []response.AnomalyItem {
+	if len(expenses) == 0 {
+		return nil
+	}
+
+	totals := make(map[string]float64)
+	for _, rec := range expenses {
+		totals[rec.Time.Format("2006-01-02")] += rec.Amount
+	}
+
+	// Days before the first recorded expense are unknown rather than zero-spend days
+	first := time.Date(expenses[0].Time.Year(), expenses[0].Time.Month(), expenses[0].Time.Day(), 0, 0, 0, 0, time.UTC)
+
+	var anomalies []response.AnomalyItem
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		total := totals[d.Format("2006-01-02")]
+		if total == 0 {
+			continue
+		}
+
+		var window []float64
+		for w := d.AddDate(0, 0, -anomalyRollingDays); w.Before(d); w = w.AddDate(0, 0, 1) {
+			if !w.Before(first) {
+				window = append(window, totals[w.Format("2006-01-02")])
+			}
+		}
+		if len(window) < anomalyMinRollingDays {
+			continue
+		}
+
+		var sum, sumSq float64
+		for _, v := range window {
+			sum += v
+			sumSq += v * v
+		}
+		avg := sum / float64(len(window))
+		std := math.Sqrt(math.Max(sumSq/float64(len(window))-avg*avg, 0))
+		if avg == 0 || std == 0 {
+			continue
+		}
+
+		score := (total - avg) / std
+		if score <= 3 || total <= 2*avg {
+			continue
+		}
+		anomalies = append(anomalies, response.AnomalyItem{
+			Type:            "daily_spike",
+			Severity:        anomalySeverity(score, 3),
+			Date:            d.Format("2006-01-02"),
+			Amount:          total,
+			AmountDisplay:   formatBalance(total, baseCurrency),
+			Expected:        math.Round(avg),
+			ExpectedDisplay: formatBalance(math.Round(avg), baseCurrency),
+			Score:           math.Round(score*10) / 10,
+			Explanation: fmt.Sprintf("Spent %s on %s, %.1fx the %d-day average of %s",
+				formatBalance(total, baseCurrency), d.Format("Jan 2, 2006"), total/avg, len(window), formatBalance(math.Round(avg), baseCurrency)),
+		})
+	}
+	return anomalies
+}
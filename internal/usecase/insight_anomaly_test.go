@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMedian(t *testing.T) {
+	tests := []struct {
+		values []float64
+		want   float64
+	}{
+		{nil, 0},
+		{[]float64{5}, 5},
+		{[]float64{30, 10, 20}, 20},
+		{[]float64{40, 10, 30, 20}, 25},
+	}
+	for _, tt := range tests {
+		if got := median(tt.values); got != tt.want {
+			t.Errorf("median(%v) = %v, want %v", tt.values, got, tt.want)
+		}
+	}
+}
+
+func TestTransactionAnomalies(t *testing.T) {
+	from := mustDate("2025-03-01")
+	expense := func(desc string, amount float64, at time.Time) transactionRecord {
+		return transactionRecord{ID: "exp_1", Type: "expense", Description: desc, Category: "Makan", Amount: amount, Time: at}
+	}
+	history := func(amounts ...float64) []transactionRecord {
+		var records []transactionRecord
+		for i, amount := range amounts {
+			records = append(records, expense("Warung", amount, mustDate("2025-02-01").AddDate(0, 0, i)))
+		}
+		return records
+	}
+	spread := history(20000, 25000, 30000, 35000, 40000) // median 30000, MAD 5000
+
+	tests := []struct {
+		name         string
+		history      []transactionRecord
+		desc         string
+		amount       float64
+		at           time.Time
+		wantType     string // empty when nothing is flagged
+		wantSeverity string
+		wantScore    float64
+	}{
+		{"usual amount", spread, "Warung", 35000, from, "", "", 0},
+		{"high score but not twice the median", spread, "Warung", 60000, from, "", "", 0},
+		{"above the threshold", spread, "Warung", 61000, from, "amount", "medium", 4.2},
+		{"twice the threshold", spread, "Warung", 90000, from, "amount", "high", 8.1},
+		{"new description above the median", spread, "Restoran", 35000, from, "description", "low", 1.2},
+		{"new description below the median", spread, "Restoran", 25000, from, "", "", 0},
+		{"too few earlier expenses", history(20000, 25000, 30000, 35000), "Warung", 90000, from, "", "", 0},
+		{"before the range only builds history", spread, "Warung", 90000, from.AddDate(0, 0, -1), "", "", 0},
+		// Without spread the amount is compared with the median: 3.5x scores 4.375
+		{"no spread at the threshold", history(20000, 20000, 20000, 20000, 20000), "Warung", 60000, from, "", "", 0},
+		{"no spread above the threshold", history(20000, 20000, 20000, 20000, 20000), "Warung", 70000, from, "amount", "medium", 4.4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expenses := append(append([]transactionRecord(nil), tt.history...), expense(tt.desc, tt.amount, tt.at))
+			anomalies := transactionAnomalies(expenses, from, "IDR")
+
+			if tt.wantType == "" {
+				if len(anomalies) != 0 {
+					t.Errorf("got %+v, want no anomaly", anomalies)
+				}
+				return
+			}
+			if len(anomalies) != 1 {
+				t.Fatalf("got %d anomalies, want 1: %+v", len(anomalies), anomalies)
+			}
+			a := anomalies[0]
+			if a.Type != tt.wantType || a.Severity != tt.wantSeverity || a.Score != tt.wantScore {
+				t.Errorf("got %s/%s/%v, want %s/%s/%v", a.Type, a.Severity, a.Score, tt.wantType, tt.wantSeverity, tt.wantScore)
+			}
+		})
+	}
+}
+
+func TestDailySpikeAnomalies(t *testing.T) {
+	day := mustDate("2025-03-31")
+	// The 30 days before alternate 50000 and 150000: average 100000, standard deviation 50000
+	window := func(days int) []transactionRecord {
+		var records []transactionRecord
+		for i := days; i > 0; i-- {
+			amount := 50000.0
+			if i%2 == 0 {
+				amount = 150000
+			}
+			records = append(records, transactionRecord{Type: "expense", Amount: amount, Time: day.AddDate(0, 0, -i)})
+		}
+		return records
+	}
+
+	tests := []struct {
+		name         string
+		history      []transactionRecord
+		total        float64
+		wantSeverity string // empty when the day is not flagged
+	}{
+		{"ordinary day", window(30), 190000, ""},
+		{"three deviations is not a spike", window(30), 250000, ""},
+		{"spike", window(30), 260000, "medium"},
+		{"six deviations", window(30), 400000, "high"},
+		{"too few known days", window(13), 400000, ""},
+		{"enough known days", window(14), 400000, "high"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The day's total is split over two expenses
+			expenses := append(append([]transactionRecord(nil), tt.history...),
+				transactionRecord{Type: "expense", Amount: tt.total / 2, Time: day.Add(9 * time.Hour)},
+				transactionRecord{Type: "expense", Amount: tt.total / 2, Time: day.Add(19 * time.Hour)},
+			)
+			anomalies := dailySpikeAnomalies(expenses, day, day, "IDR")
+
+			if tt.wantSeverity == "" {
+				if len(anomalies) != 0 {
+					t.Errorf("got %+v, want no spike", anomalies)
+				}
+				return
+			}
+			if len(anomalies) != 1 {
+				t.Fatalf("got %d anomalies, want 1: %+v", len(anomalies), anomalies)
+			}
+			if a := anomalies[0]; a.Type != "daily_spike" || a.Severity != tt.wantSeverity || a.Amount != tt.total {
+				t.Errorf("got %s/%s/%v, want daily_spike/%s/%v", a.Type, a.Severity, a.Amount, tt.wantSeverity, tt.total)
+			}
+		})
+	}
+
+	// A window without any variation never flags a spike
+	var flat []transactionRecord
+	for i := 30; i > 0; i-- {
+		flat = append(flat, transactionRecord{Type: "expense", Amount: 100000, Time: day.AddDate(0, 0, -i)})
+	}
+	flat = append(flat, transactionRecord{Type: "expense", Amount: 900000, Time: day})
+	if anomalies := dailySpikeAnomalies(flat, day, day, "IDR"); len(anomalies) != 0 {
+		t.Errorf("flat window: got %+v, want no spike", anomalies)
+	}
+}
@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"byeboros-backend/internal/adapter/repository"
+	"byeboros-backend/internal/infrastructure/gsheet"
+
+	"google.golang.org/api/option"
+	"google.golang.org/api/sheets/v4"
+)
+
+// fakeSheets is an in-memory stand-in for the Sheets API. Reads return the values stored
+// under "<spreadsheet ID>/<A1 range>", or else under the exact A1 range requested, so every
+// spreadsheet shares the same tabs. Every range read or written is recorded.
+type fakeSheets struct {
+	mu      sync.Mutex
+	tabs    []string
+	values  map[string][][]interface{}
+	reads   []string
+	writes  map[string][][]interface{}
+	cleared []string
+}
+
+// newFakeSheetRepo starts a fake Sheets server holding the given tabs and range values
+func newFakeSheetRepo(t *testing.T, tabs []string, values map[string][][]interface{}) (*repository.SheetRepository, *fakeSheets) {
+	t.Helper()
+
+	fake := &fakeSheets{tabs: tabs, values: values, writes: make(map[string][][]interface{})}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	svc, err := sheets.NewService(context.Background(),
+		option.WithEndpoint(srv.URL+"/"),
+		option.WithHTTPClient(srv.Client()),
+	)
+	if err != nil {
+		t.Fatalf("sheets.NewService: %v", err)
+	}
+	return repository.NewSheetRepository(&gsheet.Client{Service: svc}), fake
+}
+
+func (f *fakeSheets) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/v4/spreadsheets/")
+	id, rest, _ := strings.Cut(path, "/")
+
+	var body struct {
+		Data []struct {
+			Range  string          `json:"range"`
+			Values [][]interface{} `json:"values"`
+		} `json:"data"`
+		Values [][]interface{} `json:"values"`
+	}
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+	}
+
+	var resp interface{}
+	switch {
+	case rest == "" && strings.HasSuffix(id, ":batchUpdate"):
+		resp = map[string]interface{}{}
+	case rest == "":
+		var tabs []map[string]interface{}
+		for i, title := range f.tabs {
+			tabs = append(tabs, map[string]interface{}{"properties": map[string]interface{}{"title": title, "sheetId": i}})
+		}
+		resp = map[string]interface{}{"spreadsheetId": id, "sheets": tabs}
+	case rest == "values:batchGet":
+		var ranges []map[string]interface{}
+		for _, rng := range r.URL.Query()["ranges"] {
+			f.reads = append(f.reads, rng)
+			ranges = append(ranges, map[string]interface{}{"range": rng, "values": f.lookup(id, rng)})
+		}
+		resp = map[string]interface{}{"valueRanges": ranges}
+	case rest == "values:batchUpdate":
+		for _, d := range body.Data {
+			f.writes[d.Range] = d.Values
+		}
+		resp = map[string]interface{}{}
+	case strings.HasSuffix(rest, ":append"):
+		rng := strings.TrimSuffix(strings.TrimPrefix(rest, "values/"), ":append")
+		f.writes[rng] = body.Values
+		resp = map[string]interface{}{"updates": map[string]interface{}{"updatedRange": rng}}
+	case strings.HasSuffix(rest, ":clear"):
+		f.cleared = append(f.cleared, strings.TrimSuffix(strings.TrimPrefix(rest, "values/"), ":clear"))
+		resp = map[string]interface{}{}
+	case r.Method == http.MethodPut:
+		f.writes[strings.TrimPrefix(rest, "values/")] = body.Values
+		resp = map[string]interface{}{}
+	default:
+		rng := strings.TrimPrefix(rest, "values/")
+		f.reads = append(f.reads, rng)
+		resp = map[string]interface{}{"range": rng, "values": f.lookup(id, rng)}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (f *fakeSheets) lookup(id string, rng string) [][]interface{} {
+	if values, ok := f.values[id+"/"+rng]; ok {
+		return values
+	}
+	return f.values[rng]
+}
+
+// read reports whether a range was read
+func (f *fakeSheets) read(rng string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, r := range f.reads {
+		if r == rng {
+			return true
+		}
+	}
+	return false
+}
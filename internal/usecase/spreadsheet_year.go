@@ -0,0 +1,252 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"byeboros-backend/internal/adapter/http/model/request"
+	"byeboros-backend/internal/adapter/http/model/response"
+	"byeboros-backend/internal/adapter/repository"
+)
+
+// yearSpreadsheetSheet links the spreadsheets of other years to the current one. Each
+// spreadsheet holds the twelve month tabs of a single year, so analysis over a range that
+// crosses a new year needs to know where the other year's tabs live.
+const yearSpreadsheetSheet = "Spreadsheets"
+
+var yearSpreadsheetHeaders = []interface{}{"Year", "Spreadsheet ID"}
+
+// ErrYearNotLinked is returned when a year has no spreadsheet in the "Spreadsheets" sheet
+var ErrYearNotLinked = errors.New("year has no linked spreadsheet")
+
+// yearSpreadsheet is one row of the "Spreadsheets" sheet
+type yearSpreadsheet struct {
+	Row           int
+	Year          int
+	SpreadsheetID string
+}
+
+// loadYearSpreadsheets maps each year to its spreadsheet ID. The requested spreadsheet
+// holds the current year unless it is listed under another year.
+func loadYearSpreadsheets(sheetRepo *repository.SheetRepository, spreadsheetID string) (map[int]*yearSpreadsheet, error) {
+	rows, err := sheetRepo.GetOptionalRangeValuesUnformatted(spreadsheetID, yearSpreadsheetSheet+"!A2:B")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get year spreadsheets: %w", err)
+	}
+
+	years := make(map[int]*yearSpreadsheet)
+	listed := false
+	for i, row := range rows {
+		year := int(cellNumber(row, 0))
+		id := cellString(row, 1)
+		if year == 0 || id == "" {
+			continue
+		}
+		years[year] = &yearSpreadsheet{Row: i + 2, Year: year, SpreadsheetID: id}
+		listed = listed || id == spreadsheetID
+	}
+
+	if current := time.Now().Year(); !listed && years[current] == nil {
+		years[current] = &yearSpreadsheet{Year: current, SpreadsheetID: spreadsheetID}
+	}
+	return years, nil
+}
+
+// linkedSpreadsheetIDs returns the spreadsheet of every linked year, oldest year first
+func linkedSpreadsheetIDs(sheetRepo *repository.SheetRepository, spreadsheetID string) ([]string, error) {
+	years, err := loadYearSpreadsheets(sheetRepo, spreadsheetID)
+	if err != nil {
+		return nil, err
+	}
+
+	var sorted []int
+	for year := range years {
+		sorted = append(sorted, year)
+	}
+	sort.Ints(sorted)
+
+	var ids []string
+	seen := make(map[string]bool)
+	for _, year := range sorted {
+		id := years[year].SpreadsheetID
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// spreadsheetYear returns the year whose month tabs the spreadsheet holds: the year it is
+// linked under in the "Spreadsheets" sheet, or the current year
+func spreadsheetYear(sheetRepo *repository.SheetRepository, spreadsheetID string) (int, error) {
+	years, err := loadYearSpreadsheets(sheetRepo, spreadsheetID)
+	if err != nil {
+		return 0, err
+	}
+	return yearOf(years, spreadsheetID), nil
+}
+
+// yearOf returns the year a spreadsheet is linked under, or the current year
+func yearOf(years map[int]*yearSpreadsheet, spreadsheetID string) int {
+	for year, ys := range years {
+		if ys.SpreadsheetID == spreadsheetID {
+			return year
+		}
+	}
+	return time.Now().Year()
+}
+
+// monthTab is the tab holding the transactions of one calendar month
+type monthTab struct {
+	SpreadsheetID string
+	SheetName     string
+	Year          int
+	Month         time.Month
+}
+
+// monthTabsInRange returns the existing month tabs covering [from, to], in calendar order.
+// Months of years without a linked spreadsheet, or whose tab is missing, are skipped.
+func monthTabsInRange(sheetRepo *repository.SheetRepository, spreadsheetID string, from, to time.Time) ([]monthTab, error) {
+	years, err := loadYearSpreadsheets(sheetRepo, spreadsheetID)
+	if err != nil {
+		return nil, err
+	}
+
+	var tabs []monthTab
+	present := make(map[string]map[string]bool) // spreadsheet ID -> month tab names
+	last := time.Date(to.Year(), to.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for m := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC); !m.After(last); m = m.AddDate(0, 1, 0) {
+		ys, ok := years[m.Year()]
+		if !ok {
+			continue
+		}
+
+		names, ok := present[ys.SpreadsheetID]
+		if !ok {
+			sheetNames, err := monthSheetNames(sheetRepo, ys.SpreadsheetID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read spreadsheet of %d: %w", m.Year(), err)
+			}
+			names = make(map[string]bool)
+			for _, name := range sheetNames {
+				names[name] = true
+			}
+			present[ys.SpreadsheetID] = names
+		}
+
+		name := getIndonesianMonthName(int(m.Month()))
+		if !names[name] {
+			continue
+		}
+		tabs = append(tabs, monthTab{SpreadsheetID: ys.SpreadsheetID, SheetName: name, Year: m.Year(), Month: m.Month()})
+	}
+	return tabs, nil
+}
+
+// readMonthTabs reads the given cell ranges (e.g. "A2:G") of every tab, batching the reads
+// per spreadsheet. The result is indexed by tab, then by cell range.
+func readMonthTabs(sheetRepo *repository.SheetRepository, tabs []monthTab, cellRanges ...string) ([][][][]interface{}, error) {
+	values := make([][][][]interface{}, len(tabs))
+	bySpreadsheet := make(map[string][]int)
+	for i, tab := range tabs {
+		values[i] = make([][][]interface{}, len(cellRanges))
+		bySpreadsheet[tab.SpreadsheetID] = append(bySpreadsheet[tab.SpreadsheetID], i)
+	}
+
+	for id, idxs := range bySpreadsheet {
+		var ranges []string
+		for _, i := range idxs {
+			for _, r := range cellRanges {
+				ranges = append(ranges, tabs[i].SheetName+"!"+r)
+			}
+		}
+
+		valueRanges, err := sheetRepo.BatchGetValues(id, ranges)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch month tabs: %w", err)
+		}
+
+		for n, i := range idxs {
+			for k := range cellRanges {
+				pos := n*len(cellRanges) + k
+				if len(valueRanges) > pos && valueRanges[pos] != nil {
+					values[i][k] = valueRanges[pos].Values
+				}
+			}
+		}
+	}
+	return values, nil
+}
+
+// recordsInRange parses the rows read by readMonthTabs, whose first two cell ranges must be
+// the expense (A2:G) and income (I2:N) blocks, and keeps the records dated within [from, to]
+// (inclusive days)
+func recordsInRange(tabs []monthTab, values [][][][]interface{}, from, to time.Time) []transactionRecord {
+	end := to.AddDate(0, 0, 1)
+	var records []transactionRecord
+	for i, tab := range tabs {
+		parsed := append(parseExpenseRecords(tab.SheetName, values[i][0]), parseIncomeRecords(tab.SheetName, values[i][1])...)
+		for _, rec := range parsed {
+			if rec.Time.Before(from) || !rec.Time.Before(end) {
+				continue
+			}
+			rec.SpreadsheetID = tab.SpreadsheetID
+			records = append(records, rec)
+		}
+	}
+	return records
+}
+
+// GetYearSpreadsheets lists the spreadsheet linked to each year
+func (u *TransactionUsecase) GetYearSpreadsheets(spreadsheetID string) ([]response.YearSpreadsheetItem, error) {
+	years, err := loadYearSpreadsheets(u.sheetRepo, spreadsheetID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]response.YearSpreadsheetItem, 0, len(years))
+	for _, ys := range years {
+		items = append(items, response.YearSpreadsheetItem{
+			Year:          ys.Year,
+			SpreadsheetID: ys.SpreadsheetID,
+			Current:       ys.SpreadsheetID == spreadsheetID,
+		})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Year < items[j].Year
+	})
+	return items, nil
+}
+
+// SaveYearSpreadsheet links a year to a spreadsheet, replacing an existing link for that year
+func (u *TransactionUsecase) SaveYearSpreadsheet(spreadsheetID string, req request.YearSpreadsheetRequest) error {
+	years, err := loadYearSpreadsheets(u.sheetRepo, spreadsheetID)
+	if err != nil {
+		return err
+	}
+
+	// Make sure the spreadsheet is readable before linking it
+	if _, err := monthSheetNames(u.sheetRepo, req.SpreadsheetID); err != nil {
+		return fmt.Errorf("cannot read spreadsheet %s: %w", req.SpreadsheetID, err)
+	}
+
+	values := []interface{}{strconv.Itoa(req.Year), req.SpreadsheetID}
+	if existing, ok := years[req.Year]; ok && existing.Row > 0 {
+		if err := u.sheetRepo.UpdateRow(spreadsheetID, yearSpreadsheetSheet, existing.Row, values); err != nil {
+			return fmt.Errorf("failed to update year spreadsheet: %w", err)
+		}
+		return nil
+	}
+
+	if err := u.sheetRepo.EnsureSheet(spreadsheetID, yearSpreadsheetSheet, yearSpreadsheetHeaders); err != nil {
+		return err
+	}
+	if err := u.sheetRepo.AppendRow(spreadsheetID, yearSpreadsheetSheet+"!A:B", values); err != nil {
+		return fmt.Errorf("failed to save year spreadsheet: %w", err)
+	}
+	return nil
+}
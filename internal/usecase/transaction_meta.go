@@ -0,0 +1,362 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"byeboros-backend/internal/adapter/repository"
+)
+
+// transactionMetaSheet stores data attached to transaction rows that has no
+// column of its own in the month tabs (A:G expense, I:N income)
+const transactionMetaSheet = "Transaction Meta"
+
+var transactionMetaHeaders = []interface{}{"Sheet", "Transaction ID", "Split Group", "Account", "Currency", "Original Amount", "Exchange Rate", "Tags", "Attachments"}
+
+// transactionMeta is one row of the "Transaction Meta" sheet, keyed by month tab + transaction ID
+type transactionMeta struct {
+	Row           int // 1-based row in the meta sheet, 0 when not stored yet
+	SheetName     string
+	TransactionID string
+	SplitGroup    string
+	AccountID     string // empty means the default wallet
+	Currency      string // empty means the base currency
+	OriginalAmt   float64
+	Rate          float64
+	Tags          []string
+	Attachments   []string // attachment IDs, e.g. att_1a2b3c4d5e6f.jpg
+}
+
+func (m *transactionMeta) key() string {
+	return metaKey(m.SheetName, m.TransactionID)
+}
+
+// isEmpty reports whether the row carries no data besides its key
+func (m *transactionMeta) isEmpty() bool {
+	return m.SplitGroup == "" && m.AccountID == "" && m.Currency == "" && len(m.Tags) == 0 && len(m.Attachments) == 0
+}
+
+func (m *transactionMeta) values() []interface{} {
+	return []interface{}{
+		m.SheetName,                      // Column A
+		m.TransactionID,                  // Column B
+		m.SplitGroup,                     // Column C
+		m.AccountID,                      // Column D
+		m.Currency,                       // Column E
+		blankIfZero(m.OriginalAmt),       // Column F
+		blankIfZero(m.Rate),              // Column G
+		strings.Join(m.Tags, ","),        // Column H
+		strings.Join(m.Attachments, ","), // Column I
+	}
+}
+
+// blankIfZero keeps unset numeric meta cells empty instead of writing 0
+func blankIfZero(v float64) interface{} {
+	if v == 0 {
+		return ""
+	}
+	return v
+}
+
+func metaKey(sheetName, transactionID string) string {
+	return sheetName + "|" + transactionID
+}
+
+// transactionMetaIndex maps "sheet|transaction ID" to its meta row
+type transactionMetaIndex map[string]*transactionMeta
+
+// get returns the meta for a transaction, or an unsaved empty one when none exists
+func (idx transactionMetaIndex) get(sheetName, transactionID string) *transactionMeta {
+	if m, ok := idx[metaKey(sheetName, transactionID)]; ok {
+		return m
+	}
+	return &transactionMeta{SheetName: sheetName, TransactionID: transactionID}
+}
+
+// splitMembers returns the metas of a split group within a month tab, ordered by row number
+func (idx transactionMetaIndex) splitMembers(sheetName, group string) []*transactionMeta {
+	var members []*transactionMeta
+	for _, m := range idx {
+		if m.SheetName == sheetName && m.SplitGroup == group {
+			members = append(members, m)
+		}
+	}
+	sortMetasByTransactionRow(members)
+	return members
+}
+
+func sortMetasByTransactionRow(metas []*transactionMeta) {
+	sort.Slice(metas, func(i, j int) bool {
+		_, a, _ := parseTransactionID(metas[i].TransactionID)
+		_, b, _ := parseTransactionID(metas[j].TransactionID)
+		return a < b
+	})
+}
+
+// recordMetaIndex holds the meta of records read from several spreadsheets
+type recordMetaIndex struct {
+	spreadsheetID string // spreadsheet of records without a SpreadsheetID
+	indexes       map[string]transactionMetaIndex
+}
+
+// loadRecordMeta loads the meta sheet of every spreadsheet the records come from
+func loadRecordMeta(sheetRepo *repository.SheetRepository, spreadsheetID string, records []transactionRecord) (*recordMetaIndex, error) {
+	rm := &recordMetaIndex{spreadsheetID: spreadsheetID, indexes: make(map[string]transactionMetaIndex)}
+	ids := []string{spreadsheetID}
+	for _, rec := range records {
+		ids = append(ids, rec.SpreadsheetID)
+	}
+
+	for _, id := range ids {
+		if _, ok := rm.indexes[id]; ok || id == "" {
+			continue
+		}
+		idx, err := loadTransactionMeta(sheetRepo, id)
+		if err != nil {
+			return nil, err
+		}
+		rm.indexes[id] = idx
+	}
+	return rm, nil
+}
+
+// get returns the meta of a record, or an unsaved empty one when none exists
+func (rm *recordMetaIndex) get(rec transactionRecord) *transactionMeta {
+	id := rec.SpreadsheetID
+	if id == "" {
+		id = rm.spreadsheetID
+	}
+	return rm.indexes[id].get(rec.SheetName, rec.ID)
+}
+
+// loadTransactionMeta reads the whole "Transaction Meta" sheet; a missing sheet reads as empty
+func loadTransactionMeta(sheetRepo *repository.SheetRepository, spreadsheetID string) (transactionMetaIndex, error) {
+	rows, err := sheetRepo.GetOptionalRangeValuesUnformatted(spreadsheetID, transactionMetaSheet+"!A2:Z")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction meta: %w", err)
+	}
+
+	idx := make(transactionMetaIndex)
+	for i, row := range rows {
+		if len(row) < 2 {
+			continue
+		}
+		m := &transactionMeta{
+			Row:           i + 2,
+			SheetName:     cellString(row, 0),
+			TransactionID: cellString(row, 1),
+			SplitGroup:    cellString(row, 2),
+			AccountID:     cellString(row, 3),
+			Currency:      cellString(row, 4),
+			OriginalAmt:   cellNumber(row, 5),
+			Rate:          cellNumber(row, 6),
+			Tags:          parseTags(cellString(row, 7)),
+			Attachments:   parseList(cellString(row, 8)),
+		}
+		if m.SheetName == "" || m.TransactionID == "" {
+			continue
+		}
+		idx[m.key()] = m
+	}
+	return idx, nil
+}
+
+// saveTransactionMeta writes a meta row, appending it when new and clearing it when empty
+func saveTransactionMeta(sheetRepo *repository.SheetRepository, spreadsheetID string, idx transactionMetaIndex, m *transactionMeta) error {
+	if m.isEmpty() {
+		return deleteTransactionMeta(sheetRepo, spreadsheetID, idx, m)
+	}
+
+	if m.Row > 0 {
+		if err := sheetRepo.UpdateRow(spreadsheetID, transactionMetaSheet, m.Row, m.values()); err != nil {
+			return fmt.Errorf("failed to update transaction meta: %w", err)
+		}
+		idx[m.key()] = m
+		return nil
+	}
+
+	if err := sheetRepo.EnsureSheet(spreadsheetID, transactionMetaSheet, transactionMetaHeaders); err != nil {
+		return err
+	}
+	row, err := sheetRepo.AppendRowsAt(spreadsheetID, transactionMetaSheet+"!A:Z", [][]interface{}{m.values()})
+	if err != nil {
+		return fmt.Errorf("failed to add transaction meta: %w", err)
+	}
+	m.Row = row
+	idx[m.key()] = m
+	return nil
+}
+
+// deleteTransactionMeta clears a stored meta row
+func deleteTransactionMeta(sheetRepo *repository.SheetRepository, spreadsheetID string, idx transactionMetaIndex, m *transactionMeta) error {
+	delete(idx, m.key())
+	if m.Row == 0 {
+		return nil
+	}
+
+	rangeStr := fmt.Sprintf("%s!A%d:Z%d", transactionMetaSheet, m.Row, m.Row)
+	if err := sheetRepo.ClearRange(spreadsheetID, rangeStr); err != nil {
+		return fmt.Errorf("failed to delete transaction meta: %w", err)
+	}
+	m.Row = 0
+	return nil
+}
+
+// transactionExtras holds the request fields of a transaction that are kept in the meta sheet
+type transactionExtras struct {
+	AccountID string
+	Currency  string  // empty for the base currency
+	Rate      float64 // rate to the base currency, 1 for the base currency
+	Tags      []string
+}
+
+// resolveTransactionExtras validates the account and currency of a transaction request
+func resolveTransactionExtras(sheetRepo *repository.SheetRepository, spreadsheetID string, baseCurrency string, accountRef string, currency string, transactionAt string) (transactionExtras, error) {
+	accountID, err := resolveAccountID(sheetRepo, spreadsheetID, accountRef)
+	if err != nil {
+		return transactionExtras{}, err
+	}
+
+	code, rate, err := currencyConversion(sheetRepo, spreadsheetID, baseCurrency, currency, transactionAt)
+	if err != nil {
+		return transactionExtras{}, err
+	}
+
+	return transactionExtras{AccountID: accountID, Currency: code, Rate: rate}, nil
+}
+
+// convert converts an amount in the transaction currency to the base currency
+func (e transactionExtras) convert(amount float64) float64 {
+	if e.Currency == "" {
+		return amount
+	}
+	return convertToBase(amount, e.Rate)
+}
+
+// isEmpty reports whether the extras need no meta row
+func (e transactionExtras) isEmpty() bool {
+	return e.AccountID == "" && e.Currency == "" && len(e.Tags) == 0
+}
+
+// apply copies the extras onto a meta row; originalAmount is the amount in the transaction currency
+func (e transactionExtras) apply(m *transactionMeta, originalAmount float64) {
+	m.AccountID = e.AccountID
+	m.Currency = e.Currency
+	m.Tags = e.Tags
+	m.OriginalAmt = 0
+	m.Rate = 0
+	if e.Currency != "" {
+		m.OriginalAmt = originalAmount
+		m.Rate = e.Rate
+	}
+}
+
+// normalizeTags lower-cases, trims and de-duplicates free-form tags, keeping their order
+func normalizeTags(tags []string) []string {
+	var normalized []string
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(strings.ReplaceAll(tag, ",", " ")))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+// parseTags reads the comma-separated tags of a meta cell
+func parseTags(cell string) []string {
+	if cell == "" {
+		return nil
+	}
+	return normalizeTags(strings.Split(cell, ","))
+}
+
+// parseList splits a comma-separated meta cell, dropping empty entries
+func parseList(cell string) []string {
+	var items []string
+	for _, item := range strings.Split(cell, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// hasTag reports whether tags contains tag (case-insensitive)
+func hasTag(tags []string, tag string) bool {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// updateTransactionMeta applies fn to the meta of each given transaction in a month tab and saves it
+func updateTransactionMeta(sheetRepo *repository.SheetRepository, spreadsheetID string, sheetName string, transactionIDs []string, fn func(m *transactionMeta)) error {
+	idx, err := loadTransactionMeta(sheetRepo, spreadsheetID)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range transactionIDs {
+		m := idx.get(sheetName, id)
+		fn(m)
+		if err := saveTransactionMeta(sheetRepo, spreadsheetID, idx, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseTransactionID splits an ID such as txn_exp_3 into its type and 1-based sheet row
+func parseTransactionID(id string) (string, int, error) {
+	var txnType, idPrefix string
+
+	if strings.HasPrefix(id, "txn_exp_") {
+		txnType, idPrefix = "expense", "txn_exp_"
+	} else if strings.HasPrefix(id, "txn_inc_") {
+		txnType, idPrefix = "income", "txn_inc_"
+	} else {
+		return "", 0, fmt.Errorf("invalid transaction ID format: %s", id)
+	}
+
+	idNum, err := strconv.Atoi(strings.TrimPrefix(id, idPrefix))
+	if err != nil || idNum < 1 {
+		return "", 0, fmt.Errorf("invalid transaction ID number: %s", id)
+	}
+
+	// ID starts from 1, row 2 is the first data row
+	return txnType, idNum + 1, nil
+}
+
+// transactionIDForRow builds the transaction ID for a 1-based sheet row
+func transactionIDForRow(txnType string, row int) string {
+	if txnType == "income" {
+		return fmt.Sprintf("txn_inc_%d", row-1)
+	}
+	return fmt.Sprintf("txn_exp_%d", row-1)
+}
+
+// newID generates a random identifier with the given prefix (e.g. split_1a2b3c4d)
+func newID(prefix string) string {
+	b := make([]byte, 6)
+	_, _ = rand.Read(b) // crypto/rand.Read never returns an error since Go 1.24
+	return prefix + "_" + hex.EncodeToString(b)
+}
+
+// cellString returns the trimmed string value of a cell, or "" when the row is too short
+func cellString(row []interface{}, idx int) string {
+	if idx >= len(row) || row[idx] == nil {
+		return ""
+	}
+	return strings.TrimSpace(fmt.Sprintf("%v", row[idx]))
+}
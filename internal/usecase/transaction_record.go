@@ -0,0 +1,242 @@
+package usecase
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"byeboros-backend/internal/adapter/repository"
+)
+
+// transactionRecord is a normalized expense (A:G) or income (I:N) row of a month tab
+type transactionRecord struct {
+	ID            string
+	Type          string // "expense" or "income"
+	SpreadsheetID string // set when records are read across spreadsheets
+	SheetName     string
+	Description   string
+	Category      string
+	Priority      string
+	Amount        float64
+	Notes         string
+	Time          time.Time
+	CreatedBy     string
+}
+
+// parseExpenseRecords converts A2:G rows into records, skipping empty or undated rows
+func parseExpenseRecords(sheetName string, rows [][]interface{}) []transactionRecord {
+	var records []transactionRecord
+	for i, row := range rows {
+		if len(row) < 6 {
+			continue
+		}
+		t := parseDate(row[5])
+		if t.IsZero() {
+			continue
+		}
+		records = append(records, transactionRecord{
+			ID:          fmt.Sprintf("txn_exp_%d", i+1),
+			Type:        "expense",
+			SheetName:   sheetName,
+			Description: cellString(row, 0),
+			Category:    cellString(row, 1),
+			Priority:    cellString(row, 2),
+			Amount:      parseAmount(row[3]),
+			Notes:       cellString(row, 4),
+			Time:        t,
+			CreatedBy:   cellString(row, 6),
+		})
+	}
+	return records
+}
+
+// parseIncomeRecords converts I2:N rows into records, skipping empty or undated rows
+func parseIncomeRecords(sheetName string, rows [][]interface{}) []transactionRecord {
+	var records []transactionRecord
+	for i, row := range rows {
+		if len(row) < 5 {
+			continue
+		}
+		t := parseDate(row[4])
+		if t.IsZero() {
+			continue
+		}
+		records = append(records, transactionRecord{
+			ID:          fmt.Sprintf("txn_inc_%d", i+1),
+			Type:        "income",
+			SheetName:   sheetName,
+			Description: cellString(row, 0),
+			Category:    cellString(row, 1),
+			Amount:      parseAmount(row[2]),
+			Notes:       cellString(row, 3),
+			Time:        t,
+			CreatedBy:   cellString(row, 5),
+		})
+	}
+	return records
+}
+
+// monthSheetNames returns the month tabs (Januari..Desember) present in the spreadsheet
+func monthSheetNames(sheetRepo *repository.SheetRepository, spreadsheetID string) ([]string, error) {
+	titles, err := sheetRepo.ListSheetNames(spreadsheetID)
+	if err != nil {
+		return nil, err
+	}
+
+	present := make(map[string]bool)
+	for _, title := range titles {
+		present[title] = true
+	}
+
+	var names []string
+	for month := 1; month <= 12; month++ {
+		name := getIndonesianMonthName(month)
+		if present[name] {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// loadAllRecords reads the expense and income rows of every month tab in the spreadsheet
+func loadAllRecords(sheetRepo *repository.SheetRepository, spreadsheetID string) ([]transactionRecord, error) {
+	sheetNames, err := monthSheetNames(sheetRepo, spreadsheetID)
+	if err != nil {
+		return nil, err
+	}
+	if len(sheetNames) == 0 {
+		return nil, nil
+	}
+
+	var ranges []string
+	for _, name := range sheetNames {
+		ranges = append(ranges, name+"!A2:G", name+"!I2:N")
+	}
+
+	valueRanges, err := sheetRepo.BatchGetValues(spreadsheetID, ranges)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch month transactions: %w", err)
+	}
+
+	var records []transactionRecord
+	for i, name := range sheetNames {
+		if len(valueRanges) > 2*i && valueRanges[2*i] != nil {
+			records = append(records, parseExpenseRecords(name, valueRanges[2*i].Values)...)
+		}
+		if len(valueRanges) > 2*i+1 && valueRanges[2*i+1] != nil {
+			records = append(records, parseIncomeRecords(name, valueRanges[2*i+1].Values)...)
+		}
+	}
+	return records, nil
+}
+
+// parseCellTime parses a timestamp cell read with GetRangeValuesUnformatted (a serial day
+// number) or stored as text in one of the formats accepted by the API
+func parseCellTime(val interface{}) time.Time {
+	switch v := val.(type) {
+	case float64:
+		// Sheets serial dates count days since 1899-12-30
+		epoch := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+		return epoch.Add(time.Duration(v * float64(24*time.Hour))).Round(time.Second)
+	case string:
+		if t := parseDate(v); !t.IsZero() {
+			return t
+		}
+		for _, f := range []string{"2/1/2006 15:4:5", "2006-01-02"} {
+			if t, err := time.Parse(f, v); err == nil {
+				return t
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// cellNumber returns a numeric cell read with GetRangeValuesUnformatted, or 0 when missing
+func cellNumber(row []interface{}, idx int) float64 {
+	switch v := cellValue(row, idx).(type) {
+	case float64:
+		return v
+	case string:
+		f, _ := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(v), ",", "."), 64)
+		return f
+	}
+	return 0
+}
+
+// loadTransactionRecord reads a single expense or income row of a month tab by its transaction ID
+func loadTransactionRecord(sheetRepo *repository.SheetRepository, spreadsheetID string, sheetName string, id string) (*transactionRecord, error) {
+	txnType, row, err := parseTransactionID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var records []transactionRecord
+	if txnType == "expense" {
+		rows, err := sheetRepo.GetRangeValues(spreadsheetID, fmt.Sprintf("%s!A%d:G%d", sheetName, row, row))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transaction %s: %w", id, err)
+		}
+		records = parseExpenseRecords(sheetName, rows)
+	} else {
+		rows, err := sheetRepo.GetRangeValues(spreadsheetID, fmt.Sprintf("%s!I%d:N%d", sheetName, row, row))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transaction %s: %w", id, err)
+		}
+		records = parseIncomeRecords(sheetName, rows)
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("transaction %s not found in %s", id, sheetName)
+	}
+	record := records[0]
+	record.ID = id
+	return &record, nil
+}
+
+// loadRecordsInRange returns the expense and income records dated within [from, to]
+// (inclusive days), reading the month tabs of every year the range covers
+func loadRecordsInRange(sheetRepo *repository.SheetRepository, spreadsheetID string, from, to time.Time) ([]transactionRecord, error) {
+	tabs, err := monthTabsInRange(sheetRepo, spreadsheetID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := readMonthTabs(sheetRepo, tabs, "A2:G", "I2:N")
+	if err != nil {
+		return nil, err
+	}
+
+	return recordsInRange(tabs, values, from, to), nil
+}
+
+// ParseDateRange parses from/to query values (yyyy-MM-dd). An empty from defaults to the
+// first day of the current month and an empty to defaults to today. Controllers call it to
+// reject invalid ranges before running an analysis.
+func ParseDateRange(fromStr, toStr string) (time.Time, time.Time, error) {
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	var err error
+	if fromStr != "" {
+		if from, err = time.Parse("2006-01-02", fromStr); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid from date: %s", fromStr)
+		}
+	}
+	if toStr != "" {
+		if to, err = time.Parse("2006-01-02", toStr); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid to date: %s", toStr)
+		}
+	}
+	if to.Before(from) {
+		switch {
+		case toStr == "":
+			return time.Time{}, time.Time{}, fmt.Errorf("from date must not be after today when to is omitted")
+		case fromStr == "":
+			return time.Time{}, time.Time{}, fmt.Errorf("to date must not be before the first day of this month when from is omitted")
+		}
+		return time.Time{}, time.Time{}, fmt.Errorf("to date must not be before from date")
+	}
+	return from, to, nil
+}
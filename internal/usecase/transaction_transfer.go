@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"byeboros-backend/internal/adapter/repository"
+)
+
+// transferSheet stores money moved between accounts. Transfers live outside the month
+// tabs on purpose: they must not be counted by the expense/income formulas or analysis.
+const transferSheet = "Transfers"
+
+var transferHeaders = []interface{}{"ID", "Sheet", "Description", "From Account", "To Account", "Amount", "Notes", "Transaction At", "Created By"}
+
+// transfer is one row of the "Transfers" sheet
+type transfer struct {
+	Row           int // 1-based row in the transfers sheet
+	ID            string
+	SheetName     string
+	Description   string
+	FromAccountID string
+	ToAccountID   string
+	Amount        float64
+	Notes         string
+	Time          time.Time
+	CreatedBy     string
+}
+
+// isTransferID reports whether a transaction ID refers to a transfer (trf_...)
+func isTransferID(id string) bool {
+	return strings.HasPrefix(id, "trf_")
+}
+
+// loadTransfers reads every transfer; a missing sheet reads as empty
+func loadTransfers(sheetRepo *repository.SheetRepository, spreadsheetID string) ([]transfer, error) {
+	rows, err := sheetRepo.GetOptionalRangeValuesUnformatted(spreadsheetID, transferSheet+"!A2:I")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transfers: %w", err)
+	}
+
+	var transfers []transfer
+	for i, row := range rows {
+		id := cellString(row, 0)
+		if id == "" {
+			continue
+		}
+		transfers = append(transfers, transfer{
+			Row:           i + 2,
+			ID:            id,
+			SheetName:     cellString(row, 1),
+			Description:   cellString(row, 2),
+			FromAccountID: cellString(row, 3),
+			ToAccountID:   cellString(row, 4),
+			Amount:        cellNumber(row, 5),
+			Notes:         cellString(row, 6),
+			Time:          parseCellTime(cellValue(row, 7)),
+			CreatedBy:     cellString(row, 8),
+		})
+	}
+	return transfers, nil
+}
+
+// deleteTransfer clears the row of the transfer with the given ID
+func deleteTransfer(sheetRepo *repository.SheetRepository, spreadsheetID string, id string) error {
+	transfers, err := loadTransfers(sheetRepo, spreadsheetID)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range transfers {
+		if t.ID != id {
+			continue
+		}
+		rangeStr := fmt.Sprintf("%s!A%d:I%d", transferSheet, t.Row, t.Row)
+		if err := sheetRepo.ClearRange(spreadsheetID, rangeStr); err != nil {
+			return fmt.Errorf("failed to delete transfer %s: %w", id, err)
+		}
+		return nil
+	}
+	return fmt.Errorf("transfer %s not found", id)
+}
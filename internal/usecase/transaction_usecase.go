@@ -1,7 +1,10 @@
 package usecase
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math"
 	"sort"
 	"strconv"
 	"strings"
@@ -10,20 +13,31 @@ import (
 	"byeboros-backend/internal/adapter/http/model/request"
 	"byeboros-backend/internal/adapter/http/model/response"
 	"byeboros-backend/internal/adapter/repository"
+	"byeboros-backend/internal/infrastructure/blobstore"
 )
 
+// ErrSplitRequired is returned when a line of a split expense is updated without its splits
+var ErrSplitRequired = errors.New("transaction is part of a split expense, send every line in splits")
+
+// ErrTransactionType is returned when an update's type does not match the type in its ID
+var ErrTransactionType = errors.New("transaction type does not match its id")
+
 // TransactionUsecase handles transaction business logic
 type TransactionUsecase struct {
-	sheetRepo *repository.SheetRepository
+	sheetRepo    *repository.SheetRepository
+	attachments  blobstore.Store // receipt files, removed together with their transaction
+	baseCurrency string          // currency stored amounts (columns D and K) are expressed in
 }
 
 // NewTransactionUsecase creates a new TransactionUsecase
-func NewTransactionUsecase(sheetRepo *repository.SheetRepository) *TransactionUsecase {
-	return &TransactionUsecase{sheetRepo: sheetRepo}
+func NewTransactionUsecase(sheetRepo *repository.SheetRepository, attachments blobstore.Store, baseCurrency string) *TransactionUsecase {
+	return &TransactionUsecase{sheetRepo: sheetRepo, attachments: attachments, baseCurrency: baseCurrency}
 }
 
-// GetListTransaction fetches transaction data from sheet A2:G (Expense) & I2:N (Income) and formats it
-func (u *TransactionUsecase) GetListTransaction(spreadsheetID string, sheetName string, dateFilter string, categoryFilter string, typeFilter string) (*response.TransactionResponse, error) {
+// GetListTransaction fetches transaction data from sheet A2:G (Expense) & I2:N (Income),
+// plus the month's transfers, and formats it. createdByFilter keeps the transactions entered
+// by one member (the creator email in column G/N).
+func (u *TransactionUsecase) GetListTransaction(spreadsheetID string, sheetName string, dateFilter string, categoryFilter string, typeFilter string, tagFilter string, createdByFilter string) (*response.TransactionResponse, error) {
 	expenseRange := sheetName + "!A2:G"
 	incomeRange := sheetName + "!I2:N"
 
@@ -37,10 +51,9 @@ func (u *TransactionUsecase) GetListTransaction(spreadsheetID string, sheetName
 		return nil, fmt.Errorf("failed to get income transactions: %w", err)
 	}
 
-	// DEBUG: Log raw income data
-	fmt.Printf("DEBUG incomeRows count: %d\n", len(incomeRows))
-	for i, row := range incomeRows {
-		fmt.Printf("DEBUG incomeRow[%d] len=%d data=%v\n", i, len(row), row)
+	metaIdx, err := loadTransactionMeta(u.sheetRepo, spreadsheetID)
+	if err != nil {
+		return nil, err
 	}
 
 	type rawItem struct {
@@ -71,18 +84,34 @@ func (u *TransactionUsecase) GetListTransaction(spreadsheetID string, sheetName
 			if categoryFilter != "" && !strings.EqualFold(cat, categoryFilter) {
 				continue
 			}
+			createdBy := cellString(row, 6)
+			if createdByFilter != "" && !strings.EqualFold(createdBy, createdByFilter) {
+				continue
+			}
 
 			timeStr := t.Format("15:04")
 
+			id := fmt.Sprintf("txn_exp_%d", i+1)
+			meta := metaIdx.get(sheetName, id)
+			if tagFilter != "" && !hasTag(meta.Tags, tagFilter) {
+				continue
+			}
+
 			item := response.TransactionItemResponse{
-				ID:              fmt.Sprintf("txn_exp_%d", i+1),
+				ID:              id,
 				TransactionName: desc,
 				Category:        cat,
 				Time:            timeStr,
 				Amount:          -amtF,
-				AmountDisplay:   formatAmount(amtF, false),
+				AmountDisplay:   formatAmount(amtF, false, u.baseCurrency),
 				Type:            "expense",
+				SplitGroup:      meta.SplitGroup,
+				AccountID:       accountOrDefault(meta.AccountID),
+				Tags:            meta.Tags,
+				Attachments:     meta.Attachments,
+				CreatedBy:       createdBy,
 			}
+			applyOriginalCurrency(&item, meta, false, u.baseCurrency)
 			allItems = append(allItems, rawItem{item, dateStr, t})
 		}
 	}
@@ -108,23 +137,77 @@ func (u *TransactionUsecase) GetListTransaction(spreadsheetID string, sheetName
 			if categoryFilter != "" && !strings.EqualFold(cat, categoryFilter) {
 				continue
 			}
+			createdBy := cellString(row, 5)
+			if createdByFilter != "" && !strings.EqualFold(createdBy, createdByFilter) {
+				continue
+			}
 
 			timeStr := t.Format("15:04")
 
+			id := fmt.Sprintf("txn_inc_%d", i+1)
+			meta := metaIdx.get(sheetName, id)
+			if tagFilter != "" && !hasTag(meta.Tags, tagFilter) {
+				continue
+			}
+
 			item := response.TransactionItemResponse{
-				ID:              fmt.Sprintf("txn_inc_%d", i+1),
+				ID:              id,
 				TransactionName: desc,
 				Category:        cat,
 				Time:            timeStr,
 				Amount:          amtF,
-				AmountDisplay:   formatAmount(amtF, true),
+				AmountDisplay:   formatAmount(amtF, true, u.baseCurrency),
 				Type:            "income",
 				Label:           "PEMASUKAN",
+				AccountID:       accountOrDefault(meta.AccountID),
+				Tags:            meta.Tags,
+				Attachments:     meta.Attachments,
+				CreatedBy:       createdBy,
 			}
+			applyOriginalCurrency(&item, meta, true, u.baseCurrency)
 			allItems = append(allItems, rawItem{item, dateStr, t})
 		}
 	}
 
+	// Transfers only show up when no type filter is set or the filter is "transfer"
+	if typeFilter == "" || typeFilter == "transfer" {
+		transfers, err := loadTransfers(u.sheetRepo, spreadsheetID)
+		if err != nil {
+			return nil, err
+		}
+		for _, trf := range transfers {
+			if trf.SheetName != sheetName || trf.Time.IsZero() {
+				continue
+			}
+
+			dateStr := trf.Time.Format("2006-01-02")
+			if dateFilter != "" && dateStr != dateFilter {
+				continue
+			}
+			if categoryFilter != "" || tagFilter != "" {
+				continue
+			}
+			if createdByFilter != "" && !strings.EqualFold(trf.CreatedBy, createdByFilter) {
+				continue
+			}
+
+			item := response.TransactionItemResponse{
+				ID:              trf.ID,
+				TransactionName: trf.Description,
+				Time:            trf.Time.Format("15:04"),
+				Amount:          trf.Amount,
+				AmountDisplay:   formatBalance(trf.Amount, u.baseCurrency),
+				Type:            "transfer",
+				Label:           "TRANSFER",
+				Currency:        u.baseCurrency,
+				AccountID:       trf.FromAccountID,
+				ToAccountID:     trf.ToAccountID,
+				CreatedBy:       trf.CreatedBy,
+			}
+			allItems = append(allItems, rawItem{item, dateStr, trf.Time})
+		}
+	}
+
 	sort.Slice(allItems, func(i, j int) bool {
 		return allItems[i].Time.After(allItems[j].Time)
 	})
@@ -163,6 +246,24 @@ func (u *TransactionUsecase) GetListTransaction(spreadsheetID string, sheetName
 	return &response.TransactionResponse{Transactions: finalGroups}, nil
 }
 
+// applyOriginalCurrency fills the currency fields of a list item. Amount stays in the base
+// currency; foreign-currency transactions also carry their original amount and rate.
+func applyOriginalCurrency(item *response.TransactionItemResponse, meta *transactionMeta, isIncome bool, baseCurrency string) {
+	item.Currency = baseCurrency
+	if meta.Currency == "" {
+		return
+	}
+
+	sign := "-"
+	if isIncome {
+		sign = "+"
+	}
+	item.Currency = meta.Currency
+	item.OriginalAmount = meta.OriginalAmt
+	item.OriginalAmountDisplay = sign + formatMoney(meta.OriginalAmt, meta.Currency)
+	item.ExchangeRate = meta.Rate
+}
+
 func parseAmount(val interface{}) float64 {
 	switch v := val.(type) {
 	case float64:
@@ -180,12 +281,28 @@ func parseAmount(val interface{}) float64 {
 	return 0
 }
 
-func formatAmount(amount float64, isIncome bool) string {
-	absAmt := int64(amount)
-	if absAmt < 0 {
-		absAmt = -absAmt
+func formatAmount(amount float64, isIncome bool, baseCurrency string) string {
+	if isIncome {
+		return "+" + formatMoney(amount, baseCurrency)
+	}
+	return "-" + formatMoney(amount, baseCurrency)
+}
+
+// formatMoney formats the absolute value of an amount with Indonesian separators,
+// e.g. "Rp 1.500.000" or "USD 1.250,50"
+func formatMoney(amount float64, currency string) string {
+	if amount < 0 {
+		amount = -amount
+	}
+
+	decimals := ""
+	if !zeroDecimalCurrencies[currency] {
+		cents := int64(math.Round(amount*100)) % 100
+		decimals = fmt.Sprintf(",%02d", cents)
+		amount = math.Floor(math.Round(amount*100) / 100)
 	}
-	strAmt := strconv.FormatInt(absAmt, 10)
+
+	strAmt := strconv.FormatInt(int64(amount), 10)
 	var result []byte
 	for i := range strAmt {
 		if i > 0 && (len(strAmt)-i)%3 == 0 {
@@ -193,10 +310,18 @@ func formatAmount(amount float64, isIncome bool) string {
 		}
 		result = append(result, strAmt[i])
 	}
-	if isIncome {
-		return "+Rp " + string(result)
+	return currencyPrefix(currency) + " " + string(result) + decimals
+}
+
+// zeroDecimalCurrencies are displayed without fractional digits
+var zeroDecimalCurrencies = map[string]bool{"IDR": true, "JPY": true, "KRW": true, "VND": true}
+
+// currencyPrefix returns the display prefix for a currency code ("Rp" for rupiah)
+func currencyPrefix(currency string) string {
+	if currency == "IDR" {
+		return "Rp"
 	}
-	return "-Rp " + string(result)
+	return currency
 }
 
 func parseDate(val interface{}) time.Time {
@@ -254,19 +379,79 @@ func (u *TransactionUsecase) AddIncomeTransaction(spreadsheetID string, sheetNam
 		notes = *req.Notes
 	}
 
+	category, err := resolveIncomeCategory(u.sheetRepo, spreadsheetID, req.Category)
+	if err != nil {
+		return err
+	}
+
+	extras, err := resolveTransactionExtras(u.sheetRepo, spreadsheetID, u.baseCurrency, req.AccountID, req.Currency, req.TransactionAt)
+	if err != nil {
+		return err
+	}
+	extras.Tags = normalizeTags(req.Tags)
+
 	values := []interface{}{
-		req.Description,   // Column I
-		req.Category,      // Column J
-		req.Amount,        // Column K
-		notes,             // Column L
-		req.TransactionAt, // Column M
-		createdBy,         // Column N
+		req.Description,            // Column I
+		category,                   // Column J
+		extras.convert(req.Amount), // Column K (base currency)
+		notes,                      // Column L
+		req.TransactionAt,          // Column M
+		createdBy,                  // Column N
 	}
 
-	if err := u.sheetRepo.AppendRow(spreadsheetID, sheetName+"!I:N", values); err != nil {
+	row, err := u.sheetRepo.AppendRowsAt(spreadsheetID, sheetName+"!I:N", [][]interface{}{values})
+	if err != nil {
 		return fmt.Errorf("failed to add income transaction: %w", err)
 	}
 
+	if extras.isEmpty() {
+		return nil
+	}
+	return updateTransactionMeta(u.sheetRepo, spreadsheetID, sheetName, []string{transactionIDForRow("income", row)}, func(m *transactionMeta) {
+		extras.apply(m, req.Amount)
+	})
+}
+
+// AddTransferTransaction records money moved between two accounts. Transfers are stored in
+// their own sheet so they never count as income or expense.
+func (u *TransactionUsecase) AddTransferTransaction(spreadsheetID string, sheetName string, req request.TransferTransactionRequest, createdBy string) error {
+	fromAccountID, err := resolveAccountID(u.sheetRepo, spreadsheetID, req.FromAccountID)
+	if err != nil {
+		return err
+	}
+	toAccountID, err := resolveAccountID(u.sheetRepo, spreadsheetID, req.ToAccountID)
+	if err != nil {
+		return err
+	}
+	if fromAccountID == toAccountID {
+		return fmt.Errorf("from_account_id and to_account_id must be different accounts")
+	}
+
+	if err := u.sheetRepo.EnsureSheet(spreadsheetID, transferSheet, transferHeaders); err != nil {
+		return err
+	}
+
+	notes := ""
+	if req.Notes != nil {
+		notes = *req.Notes
+	}
+
+	values := []interface{}{
+		newID("trf"),                    // Column A
+		sheetName,                       // Column B
+		req.Description,                 // Column C
+		accountOrDefault(fromAccountID), // Column D
+		accountOrDefault(toAccountID),   // Column E
+		req.Amount,                      // Column F
+		notes,                           // Column G
+		req.TransactionAt,               // Column H
+		createdBy,                       // Column I
+	}
+
+	if err := u.sheetRepo.AppendRow(spreadsheetID, transferSheet+"!A:I", values); err != nil {
+		return fmt.Errorf("failed to add transfer: %w", err)
+	}
+
 	return nil
 }
 
@@ -277,63 +462,178 @@ func (u *TransactionUsecase) AddExpenseTransaction(spreadsheetID string, sheetNa
 		notes = *req.Notes
 	}
 
+	categories, err := newExpenseCategoryResolver(u.sheetRepo, spreadsheetID, req.AllowNewCategory)
+	if err != nil {
+		return err
+	}
+	if len(req.Splits) > 0 {
+		if req.Splits, err = categories.resolveSplits(req.Splits); err != nil {
+			return err
+		}
+	} else if req.Category, err = categories.resolve(req.Category); err != nil {
+		return err
+	}
+
+	extras, err := resolveTransactionExtras(u.sheetRepo, spreadsheetID, u.baseCurrency, req.AccountID, req.Currency, req.TransactionAt)
+	if err != nil {
+		return err
+	}
+	extras.Tags = normalizeTags(req.Tags)
+
+	if len(req.Splits) > 0 {
+		return u.addSplitExpense(spreadsheetID, sheetName, req, notes, createdBy, extras)
+	}
+
 	values := []interface{}{
-		req.Description,   // Column A
-		req.Category,      // Column B
-		req.Priority,      // Column C (Priority)
-		req.Amount,        // Column D
-		notes,             // Column E
-		req.TransactionAt, // Column F
-		createdBy,         // Column G
+		req.Description,            // Column A
+		req.Category,               // Column B
+		req.Priority,               // Column C (Priority)
+		extras.convert(req.Amount), // Column D (base currency)
+		notes,                      // Column E
+		req.TransactionAt,          // Column F
+		createdBy,                  // Column G
 	}
 
-	if err := u.sheetRepo.AppendRow(spreadsheetID, sheetName+"!A:G", values); err != nil {
+	row, err := u.sheetRepo.AppendRowsAt(spreadsheetID, sheetName+"!A:G", [][]interface{}{values})
+	if err != nil {
 		return fmt.Errorf("failed to add expense transaction: %w", err)
 	}
 
-	return nil
+	if extras.isEmpty() {
+		return nil
+	}
+	return updateTransactionMeta(u.sheetRepo, spreadsheetID, sheetName, []string{transactionIDForRow("expense", row)}, func(m *transactionMeta) {
+		extras.apply(m, req.Amount)
+	})
 }
 
-// UpdateTransaction updates an existing transaction (income or expense) based on ID and type
-func (u *TransactionUsecase) UpdateTransaction(spreadsheetID string, sheetName string, req request.UpdateTransactionRequest, updatedBy string) error {
-	// Parse ID to extract row number
-	// ID format: txn_exp_1, txn_inc_2, etc.
-	var rowNumber int
-	var idPrefix string
+// addSplitExpense stores each split as its own expense row (so the P:T allocation and
+// priority analysis count it in its own sub-category) and links the rows by a split group.
+func (u *TransactionUsecase) addSplitExpense(spreadsheetID string, sheetName string, req request.ExpenseTransactionRequest, notes string, createdBy string, extras transactionExtras) error {
+	var rows [][]interface{}
+	for _, split := range req.Splits {
+		rows = append(rows, splitExpenseValues(req.Description, req.Priority, notes, req.TransactionAt, createdBy, split, extras))
+	}
 
-	if strings.HasPrefix(req.ID, "txn_exp_") {
-		idPrefix = "txn_exp_"
-	} else if strings.HasPrefix(req.ID, "txn_inc_") {
-		idPrefix = "txn_inc_"
-	} else {
-		return fmt.Errorf("invalid transaction ID format: %s", req.ID)
+	startRow, err := u.sheetRepo.AppendRowsAt(spreadsheetID, sheetName+"!A:G", rows)
+	if err != nil {
+		return fmt.Errorf("failed to add expense transaction: %w", err)
+	}
+
+	ids := make([]string, len(rows))
+	originalAmounts := make(map[string]float64)
+	for i, split := range req.Splits {
+		ids[i] = transactionIDForRow("expense", startRow+i)
+		originalAmounts[ids[i]] = split.Amount
+	}
+
+	group := newID("split")
+	return updateTransactionMeta(u.sheetRepo, spreadsheetID, sheetName, ids, func(m *transactionMeta) {
+		m.SplitGroup = group
+		extras.apply(m, originalAmounts[m.TransactionID])
+	})
+}
+
+// splitExpenseValues builds the A:G row for one split of an expense
+func splitExpenseValues(description, priority, notes, transactionAt, createdBy string, split request.ExpenseSplitRequest, extras transactionExtras) []interface{} {
+	if split.Priority != "" {
+		priority = split.Priority
+	}
+	return []interface{}{
+		description,                  // Column A
+		split.Category,               // Column B
+		priority,                     // Column C (Priority)
+		extras.convert(split.Amount), // Column D (base currency)
+		notes,                        // Column E
+		transactionAt,                // Column F
+		createdBy,                    // Column G
 	}
+}
 
-	idNumStr := strings.TrimPrefix(req.ID, idPrefix)
-	idNum, err := strconv.Atoi(idNumStr)
+// UpdateTransaction updates an existing transaction (income or expense) based on ID and type.
+// The creator in column G/N is kept; updatedBy is only written to rows that have none.
+func (u *TransactionUsecase) UpdateTransaction(ctx context.Context, spreadsheetID string, sheetName string, req request.UpdateTransactionRequest, updatedBy string) error {
+	// ID format: txn_exp_1, txn_inc_2, etc.
+	txnType, rowNumber, err := parseTransactionID(req.ID)
 	if err != nil {
-		return fmt.Errorf("invalid transaction ID number: %s", req.ID)
+		return err
+	}
+	if req.Type != txnType {
+		return fmt.Errorf("%w: %s is an %s transaction", ErrTransactionType, req.ID, txnType)
 	}
 
-	// Calculate actual row number (ID starts from 1, row 2 is the first data row)
-	rowNumber = idNum + 1
+	current, err := loadTransactionRecord(u.sheetRepo, spreadsheetID, sheetName, req.ID)
+	if err != nil {
+		return err
+	}
+	createdBy := current.CreatedBy
+	if createdBy == "" {
+		createdBy = updatedBy
+	}
 
 	notes := ""
 	if req.Notes != nil {
 		notes = *req.Notes
 	}
 
+	metaIdx, err := loadTransactionMeta(u.sheetRepo, spreadsheetID)
+	if err != nil {
+		return err
+	}
+	meta := metaIdx.get(sheetName, req.ID)
+
+	// Keep the current account unless the request moves the transaction to another one
+	accountRef := req.AccountID
+	if accountRef == "" {
+		accountRef = meta.AccountID
+	}
+	// Keep the current currency unless the request sets one; the base currency code switches
+	// the transaction back to the base currency
+	currency := req.Currency
+	if currency == "" {
+		currency = meta.Currency
+	}
+	extras, err := resolveTransactionExtras(u.sheetRepo, spreadsheetID, u.baseCurrency, accountRef, currency, req.TransactionAt)
+	if err != nil {
+		return err
+	}
+
+	// Keep the current tags unless the request sets them
+	extras.Tags = meta.Tags
+	if req.Tags != nil {
+		extras.Tags = normalizeTags(req.Tags)
+	}
+
 	// Update based on transaction type
 	if req.Type == "expense" {
+		categories, err := newExpenseCategoryResolver(u.sheetRepo, spreadsheetID, req.AllowNewCategory)
+		if err != nil {
+			return err
+		}
+		if len(req.Splits) > 0 {
+			if req.Splits, err = categories.resolveSplits(req.Splits); err != nil {
+				return err
+			}
+		} else if req.Category, err = categories.resolve(req.Category); err != nil {
+			return err
+		}
+
+		if meta.SplitGroup != "" && len(req.Splits) == 0 {
+			return ErrSplitRequired
+		}
+		if meta.SplitGroup != "" || len(req.Splits) > 0 {
+			return u.updateSplitExpense(ctx, spreadsheetID, sheetName, metaIdx, meta, req, notes, createdBy, extras)
+		}
+
 		// Expense columns: A-G (Description, Category, Priority, Amount, Notes, TransactionAt, CreatedBy)
 		values := []interface{}{
 			req.Description,
 			req.Category,
 			req.Priority,
-			req.Amount,
+			extras.convert(req.Amount),
 			notes,
 			req.TransactionAt,
-			updatedBy,
+			createdBy,
 		}
 
 		rangeStr := fmt.Sprintf("%s!A%d:G%d", sheetName, rowNumber, rowNumber)
@@ -341,14 +641,22 @@ func (u *TransactionUsecase) UpdateTransaction(spreadsheetID string, sheetName s
 			return fmt.Errorf("failed to update expense transaction: %w", err)
 		}
 	} else if req.Type == "income" {
+		// A row keeps its current category even after it was removed from the master list
+		category := current.Category
+		if !strings.EqualFold(strings.TrimSpace(req.Category), current.Category) {
+			if category, err = resolveIncomeCategory(u.sheetRepo, spreadsheetID, req.Category); err != nil {
+				return err
+			}
+		}
+
 		// Income columns: I-N (Description, Category, Amount, Notes, TransactionAt, CreatedBy)
 		values := []interface{}{
 			req.Description,
-			req.Category,
-			req.Amount,
+			category,
+			extras.convert(req.Amount),
 			notes,
 			req.TransactionAt,
-			updatedBy,
+			createdBy,
 		}
 
 		rangeStr := fmt.Sprintf("%s!I%d:N%d", sheetName, rowNumber, rowNumber)
@@ -359,72 +667,131 @@ func (u *TransactionUsecase) UpdateTransaction(spreadsheetID string, sheetName s
 		return fmt.Errorf("invalid transaction type: %s (must be 'income' or 'expense')", req.Type)
 	}
 
-	return nil
+	if meta.Row == 0 && extras.isEmpty() {
+		return nil
+	}
+	extras.apply(meta, req.Amount)
+	return saveTransactionMeta(u.sheetRepo, spreadsheetID, metaIdx, meta)
 }
 
-// getIndonesianMonthName returns Indonesian month name for a given month number (1-12)
-func getIndonesianMonthName(month int) string {
-	months := []string{
-		"Januari", "Februari", "Maret", "April", "Mei", "Juni",
-		"Juli", "Agustus", "September", "Oktober", "November", "Desember",
+// updateSplitExpense rewrites every row of a split expense from req.Splits. Existing rows are
+// reused in order, leftover rows are cleared and extra splits are appended.
+func (u *TransactionUsecase) updateSplitExpense(ctx context.Context, spreadsheetID string, sheetName string, metaIdx transactionMetaIndex, meta *transactionMeta, req request.UpdateTransactionRequest, notes string, createdBy string, extras transactionExtras) error {
+	members := []*transactionMeta{meta}
+	group := meta.SplitGroup
+	if group != "" {
+		members = metaIdx.splitMembers(sheetName, group)
+	} else {
+		group = newID("split")
 	}
-	if month < 1 || month > 12 {
-		return ""
+
+	splits := req.Splits
+
+	for i, split := range splits {
+		values := splitExpenseValues(req.Description, req.Priority, notes, req.TransactionAt, createdBy, split, extras)
+
+		var target *transactionMeta
+		if i < len(members) {
+			target = members[i]
+			_, row, err := parseTransactionID(target.TransactionID)
+			if err != nil {
+				return err
+			}
+			rangeStr := fmt.Sprintf("%s!A%d:G%d", sheetName, row, row)
+			if err := u.sheetRepo.UpdateRange(spreadsheetID, rangeStr, [][]interface{}{values}); err != nil {
+				return fmt.Errorf("failed to update expense transaction: %w", err)
+			}
+		} else {
+			row, err := u.sheetRepo.AppendRowsAt(spreadsheetID, sheetName+"!A:G", [][]interface{}{values})
+			if err != nil {
+				return fmt.Errorf("failed to add expense split: %w", err)
+			}
+			target = metaIdx.get(sheetName, transactionIDForRow("expense", row))
+		}
+
+		target.SplitGroup = group
+		extras.apply(target, split.Amount)
+		if err := saveTransactionMeta(u.sheetRepo, spreadsheetID, metaIdx, target); err != nil {
+			return err
+		}
 	}
-	return months[month-1]
+
+	for _, leftover := range members[min(len(splits), len(members)):] {
+		if err := u.deleteTransactionRow(ctx, spreadsheetID, sheetName, metaIdx, leftover); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
-// getSheetNamesForPeriod returns a list of sheet names based on the period filter
-func getSheetNamesForPeriod(currentSheetName string, period string) []string {
-	now := time.Now()
+// DeleteTransaction removes a transaction by ID. Deleting any line of a split expense
+// removes the whole split so the parent total never goes out of sync.
+func (u *TransactionUsecase) DeleteTransaction(ctx context.Context, spreadsheetID string, sheetName string, id string) error {
+	if isTransferID(id) {
+		return deleteTransfer(u.sheetRepo, spreadsheetID, id)
+	}
 
-	switch period {
-	case "Day":
-		// Only return current sheet
-		return []string{currentSheetName}
+	if _, _, err := parseTransactionID(id); err != nil {
+		return err
+	}
 
-	case "Month":
-		// Only return current sheet
-		return []string{currentSheetName}
+	metaIdx, err := loadTransactionMeta(u.sheetRepo, spreadsheetID)
+	if err != nil {
+		return err
+	}
 
-	case "3 Months":
-		// Return last 3 months including current month
-		sheets := []string{}
-		for i := 2; i >= 0; i-- {
-			monthDate := now.AddDate(0, -i, 0)
-			sheetName := getIndonesianMonthName(int(monthDate.Month()))
-			if sheetName != "" {
-				sheets = append(sheets, sheetName)
-			}
-		}
-		return sheets
+	meta := metaIdx.get(sheetName, id)
+	members := []*transactionMeta{meta}
+	if meta.SplitGroup != "" {
+		members = metaIdx.splitMembers(sheetName, meta.SplitGroup)
+	}
 
-	case "6 Months":
-		// Return last 6 months including current month
-		sheets := []string{}
-		for i := 5; i >= 0; i-- {
-			monthDate := now.AddDate(0, -i, 0)
-			sheetName := getIndonesianMonthName(int(monthDate.Month()))
-			if sheetName != "" {
-				sheets = append(sheets, sheetName)
-			}
+	for _, m := range members {
+		if err := u.deleteTransactionRow(ctx, spreadsheetID, sheetName, metaIdx, m); err != nil {
+			return err
 		}
-		return sheets
+	}
+	return nil
+}
 
-	case "Year":
-		// Return all 12 months of the current year
-		sheets := []string{}
-		for month := 1; month <= 12; month++ {
-			sheetName := getIndonesianMonthName(month)
-			if sheetName != "" {
-				sheets = append(sheets, sheetName)
-			}
+// deleteTransactionRow clears a transaction's cells, its attachment files and its meta row.
+// Rows are cleared rather than removed because the expense and income blocks share sheet
+// rows and transaction IDs are derived from row numbers.
+func (u *TransactionUsecase) deleteTransactionRow(ctx context.Context, spreadsheetID string, sheetName string, metaIdx transactionMetaIndex, meta *transactionMeta) error {
+	txnType, row, err := parseTransactionID(meta.TransactionID)
+	if err != nil {
+		return err
+	}
+
+	rangeStr := fmt.Sprintf("%s!A%d:G%d", sheetName, row, row)
+	if txnType == "income" {
+		rangeStr = fmt.Sprintf("%s!I%d:N%d", sheetName, row, row)
+	}
+	if err := u.sheetRepo.ClearRange(spreadsheetID, rangeStr); err != nil {
+		return fmt.Errorf("failed to delete transaction %s: %w", meta.TransactionID, err)
+	}
+
+	// The meta row is the only reference to the files, so remove them first
+	for _, attachmentID := range meta.Attachments {
+		if err := u.attachments.Delete(ctx, attachmentKey(spreadsheetID, attachmentID)); err != nil {
+			return fmt.Errorf("failed to delete attachment %s: %w", attachmentID, err)
 		}
-		return sheets
+	}
 
-	default:
-		return []string{currentSheetName}
+	return deleteTransactionMeta(u.sheetRepo, spreadsheetID, metaIdx, meta)
+}
+
+// getIndonesianMonthName returns Indonesian month name for a given month number (1-12)
+func getIndonesianMonthName(month int) string {
+	months := []string{
+		"Januari", "Februari", "Maret", "April", "Mei", "Juni",
+		"Juli", "Agustus", "September", "Oktober", "November", "Desember",
 	}
+	if month < 1 || month > 12 {
+		return ""
+	}
+	return months[month-1]
 }
 
 // getPeriodLabel returns a display label for the period
@@ -479,105 +846,108 @@ func getDaysInPeriod(period string) float64 {
 
 // GetAnalysis fetches the financial analysis data
 func (u *TransactionUsecase) GetAnalysis(spreadsheetID string, sheetName string, period string, date string) (*response.AnalysisResponse, error) {
-	// When period=Day and a specific date is provided, filter raw transactions
+	// Day with a date filters the raw transactions of that date
 	if period == "Day" && date != "" {
 		return u.getAnalysisForDate(spreadsheetID, sheetName, date)
 	}
 
-	// Get sheet names based on period
-	sheetNames := getSheetNamesForPeriod(sheetName, period)
-
-	// For Day and Month periods, or if only one sheet
-	if period == "Day" || period == "Month" || len(sheetNames) == 1 {
-		ranges := []string{
-			sheetName + "!AA2",  // 0: total expense
-			sheetName + "!P2:T", // 1: exp categories (Nama Kategori, Sub kategori, Budget, Alokasi, Sisa Budget)
-			sheetName + "!A2:G", // 2: exp priorities
-			sheetName + "!AD2",  // 3: total income
-			sheetName + "!I2:N", // 4: inc categories/transactions
-			"Master Data!H4:H",  // 5: master income categories
-		}
-
-		valueRanges, err := u.sheetRepo.BatchGetValues(spreadsheetID, ranges)
-		if err != nil {
-			return nil, fmt.Errorf("failed to fetch analysis data: %w", err)
-		}
-
-		getVal := func(idx int) [][]interface{} {
-			if len(valueRanges) > idx && valueRanges[idx] != nil && valueRanges[idx].Values != nil {
-				return valueRanges[idx].Values
-			}
-			return [][]interface{}{}
-		}
-
-		expenseData := u.getExpenseAnalysis(getVal(0), getVal(1), getVal(2), period)
-		incomeData := u.getIncomeAnalysis(getVal(3), getVal(4), getVal(5), period)
-
-		resp := &response.AnalysisResponse{
-			Status: "success",
-			Data: response.AnalysisData{
-				Expense: expenseData,
-				Income:  incomeData,
-			},
-		}
-		return resp, nil
+	// Multi-month periods read every month tab of their range, from the spreadsheet of its year
+	if from, to, ok := periodRange(period, time.Now()); ok {
+		return u.getAnalysisForRange(spreadsheetID, from, to, period)
 	}
 
-	// For multi-month periods, aggregate data from multiple sheets
-	var allExpenseCategories [][]interface{}
-	var allExpensePriorities [][]interface{}
-	var allIncomeTransactions [][]interface{}
-
-	for _, sheet := range sheetNames {
-		ranges := []string{
-			sheet + "!P2:T", // exp categories
-			sheet + "!A2:G", // exp priorities
-			sheet + "!I2:N", // inc transactions
-		}
+	// Month, and Day without a date, read the totals of the current tab
+	ranges := []string{
+		sheetName + "!AA2",        // 0: total expense
+		sheetName + "!P2:T",       // 1: exp categories (Nama Kategori, Sub kategori, Budget, Alokasi, Sisa Budget)
+		sheetName + "!A2:G",       // 2: exp priorities
+		sheetName + "!AD2",        // 3: total income
+		sheetName + "!I2:N",       // 4: inc categories/transactions
+		masterDataSheet + "!H4:H", // 5: master income categories
+	}
 
-		valueRanges, err := u.sheetRepo.BatchGetValues(spreadsheetID, ranges)
-		if err != nil {
-			// Skip sheets that don't exist
-			continue
-		}
+	valueRanges, err := u.sheetRepo.BatchGetValues(spreadsheetID, ranges)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch analysis data: %w", err)
+	}
 
-		if len(valueRanges) > 0 && valueRanges[0] != nil && valueRanges[0].Values != nil {
-			allExpenseCategories = append(allExpenseCategories, valueRanges[0].Values...)
-		}
-		if len(valueRanges) > 1 && valueRanges[1] != nil && valueRanges[1].Values != nil {
-			allExpensePriorities = append(allExpensePriorities, valueRanges[1].Values...)
-		}
-		if len(valueRanges) > 2 && valueRanges[2] != nil && valueRanges[2].Values != nil {
-			allIncomeTransactions = append(allIncomeTransactions, valueRanges[2].Values...)
+	getVal := func(idx int) [][]interface{} {
+		if len(valueRanges) > idx && valueRanges[idx] != nil && valueRanges[idx].Values != nil {
+			return valueRanges[idx].Values
 		}
+		return [][]interface{}{}
 	}
 
-	// Get master income categories
-	masterIncRanges := []string{"Master Data!H4:H"}
-	masterIncValueRanges, err := u.sheetRepo.BatchGetValues(spreadsheetID, masterIncRanges)
+	expenseData := u.getExpenseAnalysis(getVal(0), getVal(1), getVal(2), period)
+	incomeData := u.getIncomeAnalysis(getVal(3), getVal(4), getVal(5), period)
+
+	records := append(parseExpenseRecords(sheetName, getVal(2)), parseIncomeRecords(sheetName, getVal(4))...)
+	currencies, err := u.getCurrencyTotals(spreadsheetID, records)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch master income categories: %w", err)
+		return nil, err
 	}
 
-	var masterIncData [][]interface{}
-	if len(masterIncValueRanges) > 0 && masterIncValueRanges[0] != nil && masterIncValueRanges[0].Values != nil {
-		masterIncData = masterIncValueRanges[0].Values
+	from, to, err := u.analysisRange(spreadsheetID, sheetName, period, date, "", "")
+	if err != nil {
+		return nil, err
 	}
 
-	// Calculate totals from aggregated data (no AA2/AD2 for multi-month)
-	expenseData := u.getExpenseAnalysis(nil, allExpenseCategories, allExpensePriorities, period)
-	incomeData := u.getIncomeAnalysis(nil, allIncomeTransactions, masterIncData, period)
-
 	resp := &response.AnalysisResponse{
 		Status: "success",
 		Data: response.AnalysisData{
-			Expense: expenseData,
-			Income:  incomeData,
+			From:         from.Format("2006-01-02"),
+			To:           to.Format("2006-01-02"),
+			BaseCurrency: u.baseCurrency,
+			Expense:      expenseData,
+			Income:       incomeData,
+			Currencies:   currencies,
+			CashFlow:     buildCashFlow(records, from, to, u.baseCurrency),
 		},
 	}
 	return resp, nil
 }
 
+// getCurrencyTotals sums transactions per type and original currency, giving both the
+// original amount and the converted base-currency amount that the analysis totals use
+func (u *TransactionUsecase) getCurrencyTotals(spreadsheetID string, records []transactionRecord) ([]response.AnalysisCurrencyTotal, error) {
+	metaIdx, err := loadRecordMeta(u.sheetRepo, spreadsheetID, records)
+	if err != nil {
+		return nil, err
+	}
+
+	totals := make(map[string]*response.AnalysisCurrencyTotal)
+	for _, rec := range records {
+		meta := metaIdx.get(rec)
+		currency, original := u.baseCurrency, rec.Amount
+		if meta.Currency != "" {
+			currency, original = meta.Currency, meta.OriginalAmt
+		}
+
+		key := rec.Type + "|" + currency
+		total, ok := totals[key]
+		if !ok {
+			total = &response.AnalysisCurrencyTotal{Type: rec.Type, Currency: currency}
+			totals[key] = total
+		}
+		total.OriginalAmount += original
+		total.ConvertedAmount += rec.Amount
+	}
+
+	result := make([]response.AnalysisCurrencyTotal, 0, len(totals))
+	for _, total := range totals {
+		total.OriginalAmountDisplay = formatMoney(total.OriginalAmount, total.Currency)
+		total.ConvertedAmountDisplay = formatMoney(total.ConvertedAmount, u.baseCurrency)
+		result = append(result, *total)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Type == result[j].Type {
+			return result[i].Currency < result[j].Currency
+		}
+		return result[i].Type < result[j].Type
+	})
+	return result, nil
+}
+
 // getAnalysisForDate fetches and filters transactions by a specific date (period=Day with date param).
 func (u *TransactionUsecase) getAnalysisForDate(spreadsheetID string, defaultSheetName string, date string) (*response.AnalysisResponse, error) {
 	parsedDate, err := time.Parse("2006-01-02", date)
@@ -591,11 +961,20 @@ func (u *TransactionUsecase) getAnalysisForDate(spreadsheetID string, defaultShe
 		sheetName = defaultSheetName
 	}
 
+	// Dates of another year are read from that year's spreadsheet when one is linked
+	years, err := loadYearSpreadsheets(u.sheetRepo, spreadsheetID)
+	if err != nil {
+		return nil, err
+	}
+	if ys, ok := years[parsedDate.Year()]; ok {
+		spreadsheetID = ys.SpreadsheetID
+	}
+
 	ranges := []string{
-		sheetName + "!P2:T", // 0: expense budget categories (Nama Kategori, Sub Kategori, Budget, Alokasi, Sisa)
-		sheetName + "!A2:G", // 1: expense transactions
-		sheetName + "!I2:N", // 2: income transactions
-		"Master Data!H4:H",  // 3: master income categories
+		sheetName + "!P2:T",       // 0: expense budget categories (Nama Kategori, Sub Kategori, Budget, Alokasi, Sisa)
+		sheetName + "!A2:G",       // 1: expense transactions
+		sheetName + "!I2:N",       // 2: income transactions
+		masterDataSheet + "!H4:H", // 3: master income categories
 	}
 
 	valueRanges, err := u.sheetRepo.BatchGetValues(spreadsheetID, ranges)
@@ -671,15 +1050,69 @@ func (u *TransactionUsecase) getAnalysisForDate(spreadsheetID string, defaultShe
 	incomeData := u.getIncomeAnalysis(nil, filteredIncome, getVal(3), "Day")
 	incomeData.PeriodLabel = periodLabel
 
+	var records []transactionRecord
+	for _, rec := range append(parseExpenseRecords(sheetName, getVal(1)), parseIncomeRecords(sheetName, getVal(2))...) {
+		if rec.Time.Format("2006-01-02") == date {
+			records = append(records, rec)
+		}
+	}
+	currencies, err := u.getCurrencyTotals(spreadsheetID, records)
+	if err != nil {
+		return nil, err
+	}
+
 	return &response.AnalysisResponse{
 		Status: "success",
 		Data: response.AnalysisData{
-			Expense: expenseData,
-			Income:  incomeData,
+			From:         date,
+			To:           date,
+			BaseCurrency: u.baseCurrency,
+			Expense:      expenseData,
+			Income:       incomeData,
+			Currencies:   currencies,
+			CashFlow:     buildCashFlow(records, parsedDate, parsedDate, u.baseCurrency),
 		},
 	}, nil
 }
 
+// priorityOrder lists the canonical priority levels from most to least important
+var priorityOrder = []string{"high", "medium", "low"}
+
+var priorityLabels = map[string]string{
+	"high":   "High Priority",
+	"medium": "Medium Priority",
+	"low":    "Low Priority",
+}
+
+// canonicalPriority normalizes Indonesian and English priority labels to canonical keys,
+// returning "" for anything else
+func canonicalPriority(priority string) string {
+	switch strings.ToLower(strings.TrimSpace(priority)) {
+	case "tinggi", "high":
+		return "high"
+	case "sedang", "medium":
+		return "medium"
+	case "rendah", "low":
+		return "low"
+	}
+	return ""
+}
+
+// buildPriorityDistribution lists the amount of every priority level, in priorityOrder
+func buildPriorityDistribution(amounts map[string]float64, baseCurrency string) []response.AnalysisPriorityDistribution {
+	var priDist []response.AnalysisPriorityDistribution
+	for _, key := range priorityOrder {
+		amt := amounts[key]
+		priDist = append(priDist, response.AnalysisPriorityDistribution{
+			Level:         key,
+			Label:         priorityLabels[key],
+			Amount:        amt,
+			AmountDisplay: strings.Replace(formatAmount(amt, false, baseCurrency), "-", "", 1),
+		})
+	}
+	return priDist
+}
+
 func (u *TransactionUsecase) getExpenseAnalysis(aa2, p2t, a2g [][]interface{}, period string) response.AnalysisExpenseData {
 	var totalSpent float64
 	if len(aa2) > 0 && len(aa2[0]) > 0 {
@@ -768,12 +1201,12 @@ func (u *TransactionUsecase) getExpenseAnalysis(aa2, p2t, a2g [][]interface{}, p
 			topExpCat = response.AnalysisTopCategory{
 				Name:         c.SubCategoryName,
 				Total:        c.Amount,
-				TotalDisplay: strings.Replace(formatAmount(c.Amount, false), "-", "", 1),
+				TotalDisplay: strings.Replace(formatAmount(c.Amount, false, u.baseCurrency), "-", "", 1),
 			}
 		}
 	}
 	if topExpCat.Name == "" {
-		topExpCat = response.AnalysisTopCategory{Name: "-", TotalDisplay: "Rp 0"}
+		topExpCat = response.AnalysisTopCategory{Name: "-", TotalDisplay: formatMoney(0, u.baseCurrency)}
 	}
 
 	// Pre-initialize all three priority levels so they always appear in the response
@@ -794,37 +1227,12 @@ func (u *TransactionUsecase) getExpenseAnalysis(aa2, p2t, a2g [][]interface{}, p
 		}
 		priStr := strings.TrimSpace(fmt.Sprintf("%v", row[2])) // C is index 2
 		amtF := parseAmount(row[3])                            // D is index 3
-		// Normalize Indonesian and English priority labels to canonical keys
-		var canonicalKey string
-		switch strings.ToLower(priStr) {
-		case "tinggi", "high":
-			canonicalKey = "high"
-		case "sedang", "medium":
-			canonicalKey = "medium"
-		case "rendah", "low":
-			canonicalKey = "low"
-		}
-		if canonicalKey != "" {
+		if canonicalKey := canonicalPriority(priStr); canonicalKey != "" {
 			priorityMap[canonicalKey] += amtF
 		}
 	}
 
-	priorityOrder := []string{"high", "medium", "low"}
-	priorityLabels := map[string]string{
-		"high":   "High Priority",
-		"medium": "Medium Priority",
-		"low":    "Low Priority",
-	}
-	var priDist []response.AnalysisPriorityDistribution
-	for _, key := range priorityOrder {
-		amt := priorityMap[key]
-		priDist = append(priDist, response.AnalysisPriorityDistribution{
-			Level:         key,
-			Label:         priorityLabels[key],
-			Amount:        amt,
-			AmountDisplay: strings.Replace(formatAmount(amt, false), "-", "", 1),
-		})
-	}
+	priDist := buildPriorityDistribution(priorityMap, u.baseCurrency)
 	daysDivider := getDaysInPeriod(period)
 	if daysDivider == 0 {
 		daysDivider = 1
@@ -836,7 +1244,7 @@ func (u *TransactionUsecase) getExpenseAnalysis(aa2, p2t, a2g [][]interface{}, p
 		PeriodLabel: getPeriodLabel(period),
 		Summary: response.AnalysisExpenseSummary{
 			TotalSpent:        totalSpent,
-			TotalSpentDisplay: strings.Replace(formatAmount(totalSpent, false), "-", "", 1),
+			TotalSpentDisplay: strings.Replace(formatAmount(totalSpent, false, u.baseCurrency), "-", "", 1),
 		},
 		Chart: response.AnalysisChart{
 			Categories: expCats,
@@ -845,7 +1253,7 @@ func (u *TransactionUsecase) getExpenseAnalysis(aa2, p2t, a2g [][]interface{}, p
 		DailyAverage: response.AnalysisDailyAverage{
 			Label:         "Average",
 			Amount:        dailyAvgExp,
-			AmountDisplay: strings.Replace(formatAmount(dailyAvgExp, false), "-", "", 1),
+			AmountDisplay: strings.Replace(formatAmount(dailyAvgExp, false, u.baseCurrency), "-", "", 1),
 		},
 		PriorityDistribution: priDist,
 	}
@@ -948,12 +1356,12 @@ func (u *TransactionUsecase) getIncomeAnalysis(ad2, incCatsData, masterIncData [
 			topIncCat = response.AnalysisTopCategory{
 				Name:         c.Name,
 				Total:        c.Amount,
-				TotalDisplay: strings.Replace(formatAmount(c.Amount, true), "+", "", 1),
+				TotalDisplay: strings.Replace(formatAmount(c.Amount, true, u.baseCurrency), "+", "", 1),
 			}
 		}
 	}
 	if topIncCat.Name == "" {
-		topIncCat = response.AnalysisTopCategory{Name: "-", TotalDisplay: "Rp 0"}
+		topIncCat = response.AnalysisTopCategory{Name: "-", TotalDisplay: formatMoney(0, u.baseCurrency)}
 	}
 
 	daysDivider := getDaysInPeriod(period)
@@ -967,7 +1375,7 @@ func (u *TransactionUsecase) getIncomeAnalysis(ad2, incCatsData, masterIncData [
 		PeriodLabel: getPeriodLabel(period),
 		Summary: response.AnalysisIncomeSummary{
 			TotalIncome:        totalIncome,
-			TotalIncomeDisplay: strings.Replace(formatAmount(totalIncome, true), "+", "", 1),
+			TotalIncomeDisplay: strings.Replace(formatAmount(totalIncome, true, u.baseCurrency), "+", "", 1),
 		},
 		Chart: response.AnalysisChart{
 			Categories: incCats,
@@ -976,7 +1384,7 @@ func (u *TransactionUsecase) getIncomeAnalysis(ad2, incCatsData, masterIncData [
 		DailyAverage: response.AnalysisDailyAverage{
 			Label:         "Average",
 			Amount:        dailyAvgInc,
-			AmountDisplay: strings.Replace(formatAmount(dailyAvgInc, true), "+", "", 1),
+			AmountDisplay: strings.Replace(formatAmount(dailyAvgInc, true, u.baseCurrency), "+", "", 1),
 		},
 	}
 }
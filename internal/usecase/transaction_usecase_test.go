@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"byeboros-backend/internal/adapter/http/model/request"
+)
+
+func TestUpdateTransactionRejectsTypeOtherThanTheID(t *testing.T) {
+	tests := []struct {
+		id      string
+		txnType string
+	}{
+		{"txn_inc_3", "expense"},
+		{"txn_exp_3", "income"},
+		{"txn_exp_3", ""},
+	}
+	for _, tt := range tests {
+		repo, fake := newFakeSheetRepo(t, []string{"Maret"}, nil)
+		uc := NewTransactionUsecase(repo, nil, "IDR")
+
+		err := uc.UpdateTransaction(context.Background(), "sheet-id", "Maret", request.UpdateTransactionRequest{
+			ID:       tt.id,
+			Type:     tt.txnType,
+			Category: "Makan",
+		}, "budi@example.com")
+		if !errors.Is(err, ErrTransactionType) {
+			t.Errorf("UpdateTransaction(%s as %q) error = %v, want ErrTransactionType", tt.id, tt.txnType, err)
+		}
+		if len(fake.reads) > 0 || len(fake.writes) > 0 {
+			t.Errorf("UpdateTransaction(%s as %q) touched the sheet: reads %v, writes %v", tt.id, tt.txnType, fake.reads, fake.writes)
+		}
+	}
+}